@@ -0,0 +1,360 @@
+package fyneloader
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// ActionFn is the function type used for declarative action callbacks.
+//
+// The function is given the value associated with the action kind and returns
+// the function to run when the action is triggered. Errors should be reported
+// through the context, in which case the function may return nil.
+type ActionFn func(*errctx.Context, *Loader, interface{}) func()
+
+// deferredCheck is a check which must wait until all elements have been
+// loaded, along with the path at which to report its error.
+type deferredCheck struct {
+	path  []mpath.Element
+	check func() error
+}
+
+// RegisterAction registers a new declarative action kind.
+//
+// If the function callback is nil and there already exists an action with the
+// given name, the action will be removed. This function will replace an action
+// with no error if a name is repeated.
+func (l *Loader) RegisterAction(name string, fn ActionFn) {
+	if fn == nil {
+		delete(l.actions, name)
+		return
+	}
+	l.actions[name] = fn
+}
+
+// GetActions fetches the value for the given key and interprets it as a list
+// of declarative actions.
+//
+// If the value is not an array, this returns false and the key should instead
+// be interpreted as a function name. Each entry of the array is either the name
+// of a registered func() or an object with a single key naming the action kind.
+func (l *Loader) GetActions(ctx *errctx.Context, data map[string]interface{}, key string) (func(), bool) {
+	raw, ok := data[key].([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	ctx.Path.Add(mpath.Key(key))
+	fns := make([]func(), 0, len(raw))
+	for i, v := range raw {
+		ctx.Path.Add(mpath.Index(i))
+		if fn := l.getAction(ctx, v); fn != nil {
+			fns = append(fns, fn)
+		}
+		ctx.Path.Pop()
+	}
+	ctx.Path.Pop()
+
+	return func() {
+		for _, fn := range fns {
+			fn()
+		}
+	}, true
+}
+
+// getFnVoidToVoid fetches a func() which is either a registered function or a
+// list of declarative actions.
+func (l *Loader) getFnVoidToVoid(ctx *errctx.Context, data map[string]interface{}, key string) func() {
+	if fn, ok := l.GetActions(ctx, data, key); ok {
+		return fn
+	}
+	fn, err := GetFnVoidToVoid(l, data, key)
+	ctx.ErrorWithKey(err, key)
+	return fn
+}
+
+// getFnBoolToVoid fetches a func(bool) which is either a registered function or
+// a list of declarative actions.
+func (l *Loader) getFnBoolToVoid(ctx *errctx.Context, data map[string]interface{}, key string) func(bool) {
+	if fn, ok := l.GetActions(ctx, data, key); ok {
+		return func(bool) { fn() }
+	}
+	fn, err := GetFnBoolToVoid(l, data, key)
+	ctx.ErrorWithKey(err, key)
+	return fn
+}
+
+// getFnFloat64ToVoid fetches a func(float64) which is either a registered
+// function or a list of declarative actions.
+func (l *Loader) getFnFloat64ToVoid(ctx *errctx.Context, data map[string]interface{}, key string) func(float64) {
+	if fn, ok := l.GetActions(ctx, data, key); ok {
+		return func(float64) { fn() }
+	}
+	fn, err := GetFnFloat64ToVoid(l, data, key)
+	ctx.ErrorWithKey(err, key)
+	return fn
+}
+
+// getFnStringToVoid fetches a func(string) which is either a registered
+// function or a list of declarative actions.
+func (l *Loader) getFnStringToVoid(ctx *errctx.Context, data map[string]interface{}, key string) func(string) {
+	if fn, ok := l.GetActions(ctx, data, key); ok {
+		return func(string) { fn() }
+	}
+	fn, err := GetFnStringToVoid(l, data, key)
+	ctx.ErrorWithKey(err, key)
+	return fn
+}
+
+// getFnURIToVoid fetches a func(fyne.URI) which is either a registered function
+// or a list of declarative actions.
+func (l *Loader) getFnURIToVoid(ctx *errctx.Context, data map[string]interface{}, key string) func(fyne.URI) {
+	if fn, ok := l.GetActions(ctx, data, key); ok {
+		return func(fyne.URI) { fn() }
+	}
+	fn, err := GetFnURIToVoid(l, data, key)
+	ctx.ErrorWithKey(err, key)
+	return fn
+}
+
+func (l *Loader) getAction(ctx *errctx.Context, v interface{}) func() {
+	if name, ok := v.(string); ok {
+		return actionCall(ctx, l, name)
+	}
+
+	data, err := maputil.AsObject(v)
+	if err != nil {
+		ctx.Error(maputil.InvalidTypeError{
+			Actual:   maputil.TypeName(v),
+			Expected: []string{maputil.TypeObject, maputil.TypeString},
+		})
+		return nil
+	}
+	if len(data) != 1 {
+		ctx.Error(ConflictingKeysError{Keys: maputil.Keys(data)})
+		return nil
+	}
+
+	for kind, value := range data {
+		fn, ok := l.actions[kind]
+		if !ok {
+			ctx.ErrorWithKey(UnknownActionError{Kind: kind}, kind)
+			return nil
+		}
+		ctx.Path.Add(mpath.Key(kind))
+		action := fn(ctx, l, value)
+		ctx.Path.Pop()
+		return action
+	}
+	return nil
+}
+
+// Target returns a function which fetches the element with the given ID.
+//
+// Elements may be referenced before they are created, so the existence of the
+// target is checked once the whole definition has been loaded; if it does not
+// exist or the check function returns an error, the error is reported through
+// the context at the current path.
+func (l *Loader) Target(ctx *errctx.Context, id string, check func(fyne.CanvasObject) error) func() fyne.CanvasObject {
+	objects := l.objects
+	l.deferCheck(ctx, func() error {
+		obj, ok := objects[id]
+		if !ok {
+			return UnknownTargetError{ID: id}
+		}
+		if check != nil {
+			return check(obj)
+		}
+		return nil
+	})
+	return func() fyne.CanvasObject {
+		return objects[id]
+	}
+}
+
+// deferCheck adds a check to run once the whole definition has been loaded.
+func (l *Loader) deferCheck(ctx *errctx.Context, check func() error) {
+	path := make([]mpath.Element, len(ctx.Path.Elements))
+	copy(path, ctx.Path.Elements)
+	l.deferred = append(l.deferred, deferredCheck{path: path, check: check})
+}
+
+// runDeferred runs all deferred checks, reporting errors at the paths at which
+// the checks were added.
+func (l *Loader) runDeferred(ctx *errctx.Context) {
+	saved := ctx.Path.Elements
+	for _, d := range l.deferred {
+		if err := d.check(); err != nil {
+			ctx.Path.Elements = d.path
+			ctx.Error(err)
+		}
+	}
+	ctx.Path.Elements = saved
+	l.deferred = nil
+}
+
+// registerID records the element created from data under its ID, if it has
+// one.
+func (l *Loader) registerID(ctx *errctx.Context, data map[string]interface{}, obj fyne.CanvasObject) {
+	id, ok, err := maputil.GetString(data, KeyID)
+	if err != nil {
+		ctx.ErrorWithKey(err, KeyID)
+		return
+	}
+	if !ok || obj == nil {
+		return
+	}
+	if prev, ok := l.objects[id]; ok && prev != obj {
+		ctx.ErrorWithKey(DuplicateIDError{ID: id}, KeyID)
+		return
+	}
+	l.objects[id] = obj
+}
+
+// getTargets interprets an action value as one or more element IDs.
+func getTargets(ctx *errctx.Context, l *Loader, value interface{}, check func(fyne.CanvasObject) error) []func() fyne.CanvasObject {
+	switch d := value.(type) {
+	case string:
+		return []func() fyne.CanvasObject{l.Target(ctx, d, check)}
+	case []interface{}:
+		targets := make([]func() fyne.CanvasObject, 0, len(d))
+		for i, v := range d {
+			id, err := maputil.AsString(v)
+			if err != nil {
+				ctx.ErrorWithIndex(err, i)
+				continue
+			}
+			ctx.Path.Add(mpath.Index(i))
+			targets = append(targets, l.Target(ctx, id, check))
+			ctx.Path.Pop()
+		}
+		return targets
+	}
+	ctx.Error(maputil.InvalidTypeError{
+		Actual:   maputil.TypeName(value),
+		Expected: []string{maputil.TypeString, maputil.TypeArray},
+	})
+	return nil
+}
+
+func checkDisableable(action string) func(fyne.CanvasObject) error {
+	return func(obj fyne.CanvasObject) error {
+		if _, ok := obj.(fyne.Disableable); !ok {
+			return ActionTargetError{Action: action, Target: obj}
+		}
+		return nil
+	}
+}
+
+func checkTextSetter(obj fyne.CanvasObject) error {
+	switch obj.(type) {
+	case interface{ SetText(string) }, *widget.Check:
+		return nil
+	}
+	return ActionTargetError{Action: ActionSetText, Target: obj}
+}
+
+func actionCall(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	name, err := maputil.AsString(value)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+	fn, err := GetFnVoidToVoid(l, map[string]interface{}{KeyFunc: name}, KeyFunc)
+	ctx.Error(err)
+	return fn
+}
+
+func actionShow(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	targets := getTargets(ctx, l, value, nil)
+	return func() {
+		for _, t := range targets {
+			if obj := t(); obj != nil {
+				obj.Show()
+			}
+		}
+	}
+}
+
+func actionHide(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	targets := getTargets(ctx, l, value, nil)
+	return func() {
+		for _, t := range targets {
+			if obj := t(); obj != nil {
+				obj.Hide()
+			}
+		}
+	}
+}
+
+func actionEnable(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	targets := getTargets(ctx, l, value, checkDisableable(ActionEnable))
+	return func() {
+		for _, t := range targets {
+			if d, ok := t().(fyne.Disableable); ok {
+				d.Enable()
+			}
+		}
+	}
+}
+
+func actionDisable(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	targets := getTargets(ctx, l, value, checkDisableable(ActionDisable))
+	return func() {
+		for _, t := range targets {
+			if d, ok := t().(fyne.Disableable); ok {
+				d.Disable()
+			}
+		}
+	}
+}
+
+func actionSetText(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	data, err := maputil.AsObject(value)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+	id := unpack.RequireString(ctx, data, KeyTarget)
+	text := unpack.RequireString(ctx, data, KeyText)
+	if id == "" {
+		return nil
+	}
+
+	ctx.Path.Add(mpath.Key(KeyTarget))
+	target := l.Target(ctx, id, checkTextSetter)
+	ctx.Path.Pop()
+	return func() {
+		switch w := target().(type) {
+		case interface{ SetText(string) }:
+			w.SetText(text)
+		case *widget.Check:
+			w.Text = text
+			w.Refresh()
+		}
+	}
+}
+
+func actionNavigate(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	name, err := maputil.AsString(value)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+	roots := l.roots
+	l.deferCheck(ctx, func() error {
+		if _, ok := roots[name]; !ok {
+			return UnknownTargetError{ID: name}
+		}
+		return nil
+	})
+	return func() {
+		if l.OnNavigate != nil {
+			l.OnNavigate(name, roots[name])
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestActions(t *testing.T) {
+	t.Parallel()
+	t.Run("Builtin", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+main:
+  type: vbox
+  children:
+  - type: button
+    text: Save
+    func:
+    - save
+    - show: details
+    - hide: [summary]
+    - set-text: {target: status, text: Saved}
+    - disable: save-check
+    - navigate: settings
+  - {type: label, id: summary, text: Summary}
+  - {type: label, id: details, text: Details, hidden: true}
+  - {type: label, id: status}
+  - {type: check, id: save-check}
+settings: label
+`
+		saved := false
+		navigated := ""
+		l := fyneloader.New()
+		require.NoError(t, l.RegisterFunc("save", func() { saved = true }))
+		l.OnNavigate = func(name string, obj fyne.CanvasObject) { navigated = name }
+
+		ctx := errctx.New()
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		box := roots["main"].(*fyne.Container)
+		box.Objects[0].(*widget.Button).OnTapped()
+		require.True(t, saved)
+		require.False(t, box.Objects[1].Visible())
+		require.True(t, box.Objects[2].Visible())
+		require.Equal(t, "Saved", box.Objects[3].(*widget.Label).Text)
+		require.True(t, box.Objects[4].(*widget.Check).Disabled())
+		require.Equal(t, "settings", navigated)
+	})
+	t.Run("Custom", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: check
+  func:
+  - log: checked
+`
+		var logged []string
+		l := fyneloader.New()
+		l.RegisterAction("log", func(ctx *errctx.Context, l *fyneloader.Loader, v interface{}) func() {
+			msg, _ := v.(string)
+			return func() { logged = append(logged, msg) }
+		})
+		ctx := errctx.New()
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		roots["root"].(*widget.Check).SetChecked(true)
+		require.Equal(t, []string{"checked"}, logged)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: vbox
+  children:
+  - type: button
+    func:
+    - show: missing
+    - set-text: {target: spacer, text: x}
+    - explode: everything
+  - {type: hspacer, id: spacer}
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"root.children[0].func[2].explode: 9:7: unknown action \"explode\"\n"+
+				"root.children[0].func[0].show: 7:7: unknown target \"missing\"\n"+
+				"root.children[0].func[1].set-text.target: 8:18: "+
+				"action \"set-text\" is not supported by *layout.Spacer\n",
+			sb.String(),
+		)
+	})
+	t.Run("DuplicateID", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: vbox
+  children:
+  - {type: label, id: same}
+  - {type: label, id: same}
+`
+		ctx := errctx.New()
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, fyneloader.DuplicateIDError{ID: "same"}, ctx.LastError())
+	})
+}
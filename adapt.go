@@ -0,0 +1,234 @@
+package fyneloader
+
+import (
+	"errors"
+	"math"
+	"reflect"
+
+	"fyne.io/fyne/v2"
+	"github.com/tvarney/maputil"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// GetFn fetches a function from the registered functions in the loader and
+// adapts it to the function type T using AdaptFunc. Errors returned by the
+// adapted function are passed to OnCallbackError.
+func GetFn[T any](l *Loader, data map[string]interface{}, key string) (T, error) {
+	var zero T
+	fnname, ok, err := maputil.GetString(data, key)
+	if err != nil || !ok {
+		return zero, err
+	}
+	fni, err := l.GetFunc(fnname)
+	if err != nil {
+		return zero, err
+	}
+
+	fn, err := AdaptFunc[T](fni, func(err error) {
+		l.callbackError(fnname, err)
+	})
+	var serr FunctionSignatureError
+	if errors.As(err, &serr) {
+		serr.Name = fnname
+		return zero, serr
+	}
+	return fn, err
+}
+
+// callbackError reports an error returned by the registered function with the
+// given name.
+func (l *Loader) callbackError(name string, err error) {
+	if l.OnCallbackError != nil {
+		l.OnCallbackError(name, err)
+		return
+	}
+	fyne.LogError("function "+name+" failed", err)
+}
+
+// AdaptFunc returns fn as a function of type T. Functions which are not of
+// type T are wrapped if they are compatible with it:
+//
+//   - they may take fewer parameters than T, in which case the remaining
+//     arguments are dropped, so a func() may be used as a func(bool);
+//   - each parameter must be assignable from the matching parameter of T, or
+//     both must be numbers, so a func(int) may be used as a func(float64);
+//     floating point values are rounded to the nearest integer, and values
+//     out of the range of the parameter are passed to onError as a
+//     ConversionError instead of calling fn, returning zero values;
+//   - they must return the same results as T, or return only an error if T has
+//     no results, in which case non-nil errors are passed to onError.
+//
+// If fn is not compatible with T, a FunctionSignatureError is returned.
+func AdaptFunc[T any](fn interface{}, onError func(error)) (T, error) {
+	var zero T
+	target := reflect.TypeOf(&zero).Elem()
+	v, err := adaptFunc(reflect.ValueOf(fn), target, onError)
+	if err != nil {
+		return zero, err
+	}
+	return v.Interface().(T), nil
+}
+
+func adaptFunc(fv reflect.Value, target reflect.Type, onError func(error)) (reflect.Value, error) {
+	if !fv.IsValid() || fv.Kind() != reflect.Func || target.Kind() != reflect.Func {
+		var fn interface{}
+		if fv.IsValid() {
+			fn = fv.Interface()
+		}
+		return reflect.Value{}, FunctionTypeError{Func: fn}
+	}
+	ft := fv.Type()
+	if ft.AssignableTo(target) {
+		return fv.Convert(target), nil
+	}
+
+	fail := FunctionSignatureError{Expected: target, Actual: ft}
+	if ft.IsVariadic() || ft.NumIn() > target.NumIn() {
+		return reflect.Value{}, fail
+	}
+	convs := make([]func(reflect.Value) (reflect.Value, error), ft.NumIn())
+	for i := range convs {
+		if convs[i] = converter(target.In(i), ft.In(i)); convs[i] == nil {
+			return reflect.Value{}, fail
+		}
+	}
+
+	var results func([]reflect.Value) []reflect.Value
+	switch {
+	case sameResults(ft, target):
+		results = func(out []reflect.Value) []reflect.Value {
+			for i := range out {
+				out[i] = out[i].Convert(target.Out(i))
+			}
+			return out
+		}
+	case target.NumOut() == 0 && ft.NumOut() == 1 && ft.Out(0) == errorType:
+		results = func(out []reflect.Value) []reflect.Value {
+			if err, _ := out[0].Interface().(error); err != nil && onError != nil {
+				onError(err)
+			}
+			return nil
+		}
+	default:
+		return reflect.Value{}, fail
+	}
+
+	return reflect.MakeFunc(target, func(args []reflect.Value) []reflect.Value {
+		in := make([]reflect.Value, len(convs))
+		for i, conv := range convs {
+			var err error
+			if in[i], err = conv(args[i]); err != nil {
+				if onError != nil {
+					onError(err)
+				}
+				return zeroResults(target)
+			}
+		}
+		return results(fv.Call(in))
+	}), nil
+}
+
+// sameResults returns true if each result of fn is assignable to the matching
+// result of target.
+func sameResults(fn, target reflect.Type) bool {
+	if fn.NumOut() != target.NumOut() {
+		return false
+	}
+	for i := 0; i < fn.NumOut(); i++ {
+		if !fn.Out(i).AssignableTo(target.Out(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// zeroResults returns the zero values of the results of fn.
+func zeroResults(fn reflect.Type) []reflect.Value {
+	out := make([]reflect.Value, fn.NumOut())
+	for i := range out {
+		out[i] = reflect.Zero(fn.Out(i))
+	}
+	return out
+}
+
+// converter returns a function converting values of one type to another, or
+// nil if they may not be converted.
+func converter(from, to reflect.Type) func(reflect.Value) (reflect.Value, error) {
+	switch {
+	case from.AssignableTo(to):
+		return func(v reflect.Value) (reflect.Value, error) {
+			return v.Convert(to), nil
+		}
+	case isNumber(from) && isNumber(to):
+		return func(v reflect.Value) (reflect.Value, error) {
+			return convertNumber(v, to)
+		}
+	}
+	return nil
+}
+
+// convertNumber converts the number v to the numeric type to, rounding floating
+// point values converted to integers. A ConversionError is returned if v is
+// out of the range of to.
+func convertNumber(v reflect.Value, to reflect.Type) (reflect.Value, error) {
+	if isFloat(v.Type()) && isInteger(to) {
+		v = reflect.ValueOf(math.Round(v.Float()))
+	}
+	if !inRange(v, to) {
+		return reflect.Value{}, ConversionError{Value: v.Interface(), Type: to}
+	}
+	return v.Convert(to), nil
+}
+
+// inRange returns true if the number v may be converted to the numeric type to
+// without overflowing.
+func inRange(v reflect.Value, to reflect.Type) bool {
+	zero := reflect.Zero(to)
+	switch {
+	case isFloat(to):
+		return !isFloat(v.Type()) || !zero.OverflowFloat(v.Float())
+	case isUnsigned(to):
+		switch {
+		case isFloat(v.Type()):
+			f := v.Float()
+			return f >= 0 && f < math.Exp2(64) && !zero.OverflowUint(uint64(f))
+		case isUnsigned(v.Type()):
+			return !zero.OverflowUint(v.Uint())
+		}
+		return v.Int() >= 0 && !zero.OverflowUint(uint64(v.Int()))
+	}
+	switch {
+	case isFloat(v.Type()):
+		f := v.Float()
+		return f >= -math.Exp2(63) && f < math.Exp2(63) && !zero.OverflowInt(int64(f))
+	case isUnsigned(v.Type()):
+		return v.Uint() <= math.MaxInt64 && !zero.OverflowInt(int64(v.Uint()))
+	}
+	return !zero.OverflowInt(v.Int())
+}
+
+func isFloat(t reflect.Type) bool {
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isUnsigned(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isNumber(t reflect.Type) bool {
+	return isFloat(t) || isInteger(t)
+}
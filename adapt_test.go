@@ -0,0 +1,164 @@
+package fyneloader_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+type handler func(bool)
+
+func TestAdaptFunc(t *testing.T) {
+	t.Parallel()
+	t.Run("Exact", func(t *testing.T) {
+		t.Parallel()
+		called := false
+		fn, err := fyneloader.AdaptFunc[func(bool)](handler(func(v bool) { called = v }), nil)
+		require.NoError(t, err)
+		fn(true)
+		require.True(t, called)
+	})
+	t.Run("FewerParams", func(t *testing.T) {
+		t.Parallel()
+		count := 0
+		fn, err := fyneloader.AdaptFunc[func(bool)](func() { count++ }, nil)
+		require.NoError(t, err)
+		fn(true)
+		fn(false)
+		require.Equal(t, 2, count)
+	})
+	t.Run("Numbers", func(t *testing.T) {
+		t.Parallel()
+		var got []int
+		fn, err := fyneloader.AdaptFunc[func(float64)](func(v int) { got = append(got, v) }, nil)
+		require.NoError(t, err)
+		fn(2.6)
+		fn(-1.4)
+		require.Equal(t, []int{3, -1}, got)
+
+		var f32 float32
+		fn, err = fyneloader.AdaptFunc[func(float64)](func(v float32) { f32 = v }, nil)
+		require.NoError(t, err)
+		fn(0.5)
+		require.Equal(t, float32(0.5), f32)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		t.Parallel()
+		var got []uint
+		var reported []error
+		onError := func(err error) { reported = append(reported, err) }
+		fn, err := fyneloader.AdaptFunc[func(float64)](func(v uint) { got = append(got, v) }, onError)
+		require.NoError(t, err)
+		fn(-0.4)
+		fn(-1)
+		fn(1e20)
+		require.Equal(t, []uint{0}, got)
+		require.Equal(t, []error{
+			fyneloader.ConversionError{Value: -1.0, Type: reflect.TypeOf(uint(0))},
+			fyneloader.ConversionError{Value: 1e20, Type: reflect.TypeOf(uint(0))},
+		}, reported)
+
+		var i8 int8
+		ifn, err := fyneloader.AdaptFunc[func(int) int](func(v int8) int { i8 = v; return 1 }, onError)
+		require.NoError(t, err)
+		require.Equal(t, 1, ifn(-128))
+		require.Equal(t, 0, ifn(200))
+		require.Equal(t, int8(-128), i8)
+		require.Equal(t, fyneloader.ConversionError{Value: 200, Type: reflect.TypeOf(int8(0))}, reported[2])
+		require.Equal(t, "cannot convert 200 to int8", reported[2].Error())
+		require.Equal(t, "FL136", fyneloader.ErrorCode(reported[2]))
+	})
+	t.Run("Interface", func(t *testing.T) {
+		t.Parallel()
+		var got interface{}
+		fn, err := fyneloader.AdaptFunc[func(string)](func(v interface{}) { got = v }, nil)
+		require.NoError(t, err)
+		fn("value")
+		require.Equal(t, "value", got)
+	})
+	t.Run("ReturnsError", func(t *testing.T) {
+		t.Parallel()
+		errFailed := errors.New("failed")
+		var reported []error
+		fn, err := fyneloader.AdaptFunc[func(string)](func(v string) error {
+			if v == "" {
+				return errFailed
+			}
+			return nil
+		}, func(err error) { reported = append(reported, err) })
+		require.NoError(t, err)
+		fn("ok")
+		fn("")
+		require.Equal(t, []error{errFailed}, reported)
+	})
+	t.Run("Incompatible", func(t *testing.T) {
+		t.Parallel()
+		for _, fn := range []interface{}{
+			func(string) {},
+			func(bool, bool) {},
+			func(bool) bool { return false },
+			func(...bool) {},
+		} {
+			_, err := fyneloader.AdaptFunc[func(bool)](fn, nil)
+			require.Equal(t, fyneloader.FunctionSignatureError{
+				Expected: reflect.TypeOf(func(bool) {}),
+				Actual:   reflect.TypeOf(fn),
+			}, err)
+		}
+		_, err := fyneloader.AdaptFunc[func(bool)](true, nil)
+		require.Equal(t, fyneloader.FunctionTypeError{Func: true}, err)
+	})
+	t.Run("Loader", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+root:
+  type: vbox
+  children:
+  - type: check
+    func: toggle
+  - type: slider
+    max: 100
+    func: setLevel
+  - type: button
+    func: rename
+`
+		l := fyneloader.New()
+		toggled, level := 0, 0
+		errToggle := errors.New("toggle failed")
+		require.NoError(t, l.RegisterFunc("toggle", func() error {
+			toggled++
+			return errToggle
+		}))
+		require.NoError(t, l.RegisterFunc("setLevel", func(v int) { level = v }))
+		require.NoError(t, l.RegisterFunc("rename", func(string) {}))
+		var failures []string
+		l.OnCallbackError = func(name string, err error) {
+			failures = append(failures, name+": "+err.Error())
+		}
+
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := l.ReadYAML(ctx, strings.NewReader(def))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"root.children[2].func: 11:5: function \"rename\" has signature func(string); expected func()\n",
+			sb.String(),
+		)
+		require.Equal(t, "FL129", fyneloader.ErrorCode(ctx.LastError()))
+
+		box := roots["root"].(*fyne.Container)
+		box.Objects[0].(*widget.Check).SetChecked(true)
+		require.Equal(t, 1, toggled)
+		require.Equal(t, []string{"toggle: toggle failed"}, failures)
+		box.Objects[1].(*widget.Slider).SetValue(42)
+		require.Equal(t, 42, level)
+	})
+}
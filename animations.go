@@ -0,0 +1,418 @@
+package fyneloader
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// defaultAnimationDuration is the duration of animations which do not give
+// one.
+const defaultAnimationDuration = 300 * time.Millisecond
+
+// animationProperties lists the properties which may be animated.
+var animationProperties = []string{ValueColor, ValueOpacity, ValuePosition, ValueSize}
+
+// animationCurves maps the curve names accepted in animations to the fyne
+// animation curves.
+var animationCurves = map[string]fyne.AnimationCurve{
+	ValueEaseIn:    fyne.AnimationEaseIn,
+	ValueEaseInOut: fyne.AnimationEaseInOut,
+	ValueEaseOut:   fyne.AnimationEaseOut,
+	ValueLinear:    fyne.AnimationLinear,
+}
+
+// Animation is an animation defined in the animations section of a definition
+// file.
+//
+// The target of the animation is looked up each time it is started, and the
+// fyne animation is created at that time.
+type Animation struct {
+	Duration    time.Duration
+	Curve       fyne.AnimationCurve
+	RepeatCount int
+	AutoReverse bool
+	AutoStart   bool
+
+	target  func() fyne.CanvasObject
+	tick    func(fyne.CanvasObject) func(float32)
+	running *fyne.Animation
+}
+
+// Start starts the animation, restarting it if it is already running. It does
+// nothing if no fyne app has been created, as animations are run by its driver.
+func (a *Animation) Start() {
+	a.Stop()
+	if fyne.CurrentApp() == nil {
+		return
+	}
+	obj := a.target()
+	if obj == nil {
+		return
+	}
+	a.running = &fyne.Animation{
+		AutoReverse: a.AutoReverse,
+		Curve:       a.Curve,
+		Duration:    a.Duration,
+		RepeatCount: a.RepeatCount,
+		Tick:        a.tick(obj),
+	}
+	a.running.Start()
+}
+
+// Stop stops the animation if it is running.
+func (a *Animation) Stop() {
+	if a.running != nil {
+		a.running.Stop()
+		a.running = nil
+	}
+}
+
+// StartAnimations starts the animations of the document which have auto-start
+// set. It should be called once the app has been created, as animations are
+// not started while loading.
+func (d *Document) StartAnimations() {
+	for _, a := range d.Animations {
+		if a.AutoStart {
+			a.Start()
+		}
+	}
+}
+
+// readAnimations reads the animations section of a definition file.
+func (l *Loader) readAnimations(ctx *errctx.Context, raw interface{}) {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	for name, v := range data {
+		ctx.Path.Add(mpath.Key(name))
+		if a := l.readAnimation(ctx, v); a != nil {
+			l.animations[name] = a
+		}
+		ctx.Path.Pop()
+	}
+}
+
+func (l *Loader) readAnimation(ctx *errctx.Context, raw interface{}) *Animation {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	a := &Animation{
+		Duration:    getDuration(ctx, data, KeyDuration, defaultAnimationDuration),
+		Curve:       fyne.AnimationEaseInOut,
+		RepeatCount: getRepeatCount(ctx, data),
+		AutoReverse: unpack.OptionalBoolean(ctx, data, KeyAutoReverse, false),
+		AutoStart:   unpack.OptionalBoolean(ctx, data, KeyAutoStart, false),
+	}
+	curve := unpack.OptionalStringEnum(
+		ctx, data, KeyCurve, []string{ValueEaseIn, ValueEaseInOut, ValueEaseOut, ValueLinear}, ValueEaseInOut,
+	)
+	a.Curve = animationCurves[curve]
+
+	id := unpack.RequireString(ctx, data, KeyTarget)
+	property := unpack.RequireStringEnum(ctx, data, KeyProperty, animationProperties)
+	if _, ok := data[KeyTo]; !ok {
+		ctx.ErrorWithKey(maputil.MissingRequiredValueError{Key: KeyTo}, KeyTo)
+		return nil
+	}
+
+	var check func(fyne.CanvasObject) error
+	switch property {
+	case ValueColor:
+		from, to, ok := getAnimationValues(ctx, data, getColorValue)
+		if !ok {
+			return nil
+		}
+		check = checkColored
+		a.tick = func(obj fyne.CanvasObject) func(float32) {
+			start := toNRGBA(getObjectColor(obj))
+			if from != nil {
+				start = from.(color.NRGBA)
+			}
+			end := to.(color.NRGBA)
+			return func(f float32) {
+				setObjectColor(obj, lerpColor(start, end, f))
+			}
+		}
+	case ValueOpacity:
+		from, to, ok := getAnimationValues(ctx, data, getOpacityValue)
+		if !ok {
+			return nil
+		}
+		check = checkColored
+		// The alpha of each target is saved the first time any animation of
+		// the document changes its opacity, so that runs scale the original
+		// alpha rather than the one left by the previous run.
+		alphas := l.alphas
+		a.tick = func(obj fyne.CanvasObject) func(float32) {
+			alpha, ok := alphas[obj]
+			if !ok {
+				alpha = toNRGBA(getObjectColor(obj)).A
+				alphas[obj] = alpha
+			}
+			start := float32(1.0)
+			if from != nil {
+				start = from.(float32)
+			}
+			end := to.(float32)
+			return func(f float32) {
+				c := toNRGBA(getObjectColor(obj))
+				c.A = uint8(float32(alpha) * lerp(start, end, f))
+				setObjectColor(obj, c)
+			}
+		}
+	case ValuePosition:
+		from, to, ok := getAnimationValues(ctx, data, getPositionValue)
+		if !ok {
+			return nil
+		}
+		a.tick = func(obj fyne.CanvasObject) func(float32) {
+			start := obj.Position()
+			if from != nil {
+				start = from.(fyne.Position)
+			}
+			end := to.(fyne.Position)
+			return func(f float32) {
+				obj.Move(fyne.NewPos(lerp(start.X, end.X, f), lerp(start.Y, end.Y, f)))
+			}
+		}
+	case ValueSize:
+		from, to, ok := getAnimationValues(ctx, data, getSizeValue)
+		if !ok {
+			return nil
+		}
+		a.tick = func(obj fyne.CanvasObject) func(float32) {
+			start := obj.Size()
+			if from != nil {
+				start = from.(fyne.Size)
+			}
+			end := to.(fyne.Size)
+			return func(f float32) {
+				obj.Resize(fyne.NewSize(lerp(start.Width, end.Width, f), lerp(start.Height, end.Height, f)))
+			}
+		}
+	default:
+		return nil
+	}
+	if id == "" {
+		return nil
+	}
+
+	ctx.Path.Add(mpath.Key(KeyTarget))
+	a.target = l.Target(ctx, id, check)
+	ctx.Path.Pop()
+	return a
+}
+
+// getAnimationValues interprets the from and to values of an animation with
+// the given function. The from value is nil if it is not given, in which case
+// the animation starts from the current value of its target.
+func getAnimationValues(
+	ctx *errctx.Context, data map[string]interface{}, get func(interface{}) (interface{}, error),
+) (interface{}, interface{}, bool) {
+	var from interface{}
+	if raw, ok := data[KeyFrom]; ok {
+		v, err := get(raw)
+		if err != nil {
+			ctx.ErrorWithKey(err, KeyFrom)
+			return nil, nil, false
+		}
+		from = v
+	}
+	to, err := get(data[KeyTo])
+	if err != nil {
+		ctx.ErrorWithKey(err, KeyTo)
+		return nil, nil, false
+	}
+	return from, to, true
+}
+
+func getColorValue(v interface{}) (interface{}, error) {
+	s, err := maputil.AsString(v)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ParseColor(s)
+	if err != nil {
+		return nil, err
+	}
+	return toNRGBA(c), nil
+}
+
+func getOpacityValue(v interface{}) (interface{}, error) {
+	f, err := maputil.AsNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	if f < 0 || f > 1 {
+		return nil, ErrOpacityRange
+	}
+	return float32(f), nil
+}
+
+func getPositionValue(v interface{}) (interface{}, error) {
+	data, err := maputil.AsObject(v)
+	if err != nil {
+		return nil, err
+	}
+	x, err := maputil.RequireNumber(data, KeyX)
+	if err != nil {
+		return nil, err
+	}
+	y, err := maputil.RequireNumber(data, KeyY)
+	if err != nil {
+		return nil, err
+	}
+	return fyne.NewPos(float32(x), float32(y)), nil
+}
+
+func getSizeValue(v interface{}) (interface{}, error) {
+	data, err := maputil.AsObject(v)
+	if err != nil {
+		return nil, err
+	}
+	w, err := maputil.RequireNumber(data, KeyWidth)
+	if err != nil {
+		return nil, err
+	}
+	h, err := maputil.RequireNumber(data, KeyHeight)
+	if err != nil {
+		return nil, err
+	}
+	return fyne.NewSize(float32(w), float32(h)), nil
+}
+
+// getDuration fetches a duration given either as a string such as `500ms` or
+// as a number of seconds.
+func getDuration(ctx *errctx.Context, data map[string]interface{}, key string, def time.Duration) time.Duration {
+	switch v := data[key].(type) {
+	case nil:
+		return def
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			ctx.ErrorWithKey(err, key)
+			return def
+		}
+		return d
+	}
+	seconds, err := maputil.AsNumber(data[key])
+	if err != nil {
+		ctx.ErrorWithKey(maputil.InvalidTypeError{
+			Actual:   maputil.TypeName(data[key]),
+			Expected: []string{maputil.TypeString, maputil.TypeNumber},
+		}, key)
+		return def
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// getRepeatCount fetches the repeat key, which is either true to repeat
+// forever or the number of times to repeat.
+func getRepeatCount(ctx *errctx.Context, data map[string]interface{}) int {
+	if b, ok := data[KeyRepeat].(bool); ok {
+		if b {
+			return fyne.AnimationRepeatForever
+		}
+		return 0
+	}
+	return int(unpack.OptionalInteger(ctx, data, KeyRepeat, 0))
+}
+
+func checkColored(obj fyne.CanvasObject) error {
+	if getObjectColor(obj) == nil {
+		return AnimationTargetError{Target: obj}
+	}
+	return nil
+}
+
+// getObjectColor returns the main color of a canvas object, or nil if it does
+// not have one.
+func getObjectColor(obj fyne.CanvasObject) color.Color {
+	switch o := obj.(type) {
+	case *canvas.Rectangle:
+		return o.FillColor
+	case *canvas.Circle:
+		return o.FillColor
+	case *canvas.Text:
+		return o.Color
+	case *canvas.Line:
+		return o.StrokeColor
+	}
+	return nil
+}
+
+func setObjectColor(obj fyne.CanvasObject, c color.Color) {
+	switch o := obj.(type) {
+	case *canvas.Rectangle:
+		o.FillColor = c
+	case *canvas.Circle:
+		o.FillColor = c
+	case *canvas.Text:
+		o.Color = c
+	case *canvas.Line:
+		o.StrokeColor = c
+	default:
+		return
+	}
+	obj.Refresh()
+}
+
+func toNRGBA(c color.Color) color.NRGBA {
+	if c == nil {
+		return color.NRGBA{}
+	}
+	return color.NRGBAModel.Convert(c).(color.NRGBA)
+}
+
+func lerp(a, b, f float32) float32 {
+	return a + (b-a)*f
+}
+
+func lerpColor(a, b color.NRGBA, f float32) color.NRGBA {
+	return color.NRGBA{
+		R: uint8(lerp(float32(a.R), float32(b.R), f)),
+		G: uint8(lerp(float32(a.G), float32(b.G), f)),
+		B: uint8(lerp(float32(a.B), float32(b.B), f)),
+		A: uint8(lerp(float32(a.A), float32(b.A), f)),
+	}
+}
+
+func actionStartAnimation(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	return animationAction(ctx, l, value, (*Animation).Start)
+}
+
+func actionStopAnimation(ctx *errctx.Context, l *Loader, value interface{}) func() {
+	return animationAction(ctx, l, value, (*Animation).Stop)
+}
+
+func animationAction(ctx *errctx.Context, l *Loader, value interface{}, fn func(*Animation)) func() {
+	name, err := maputil.AsString(value)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+	animations := l.animations
+	l.deferCheck(ctx, func() error {
+		if _, ok := animations[name]; !ok {
+			return UndefinedAnimationError{Name: name}
+		}
+		return nil
+	})
+	return func() {
+		if a, ok := animations[name]; ok {
+			fn(a)
+		}
+	}
+}
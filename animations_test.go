@@ -0,0 +1,144 @@
+package fyneloader_test
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestAnimations(t *testing.T) {
+	t.Parallel()
+	t.Run("Load", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+animations:
+  pulse:
+    target: light
+    property: color
+    from: "#ff0000"
+    to: "#00ff00"
+    duration: 500ms
+    curve: linear
+    repeat: true
+    auto-reverse: true
+  fade:
+    target: light
+    property: opacity
+    to: 0.5
+    duration: 2
+    repeat: 3
+  slide:
+    target: box
+    property: position
+    to: {x: 10, y: 20}
+  grow:
+    target: box
+    property: size
+    from: {width: 1, height: 1}
+    to: {width: 30, height: 40}
+    auto-start: true
+root:
+  type: vbox
+  children:
+  - type: rectangle
+    id: light
+    color: "#123456"
+    stroke-color: "#fff"
+    stroke-width: 2
+    min-size: {width: 16, height: 16}
+  - {type: label, id: box}
+  - type: button
+    func:
+    - start-animation: pulse
+    - stop-animation: fade
+`
+		ctx := errctx.New()
+		doc, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Len(t, doc.Animations, 4)
+
+		pulse := doc.Animations["pulse"]
+		require.Equal(t, 500*time.Millisecond, pulse.Duration)
+		require.Equal(t, fyne.AnimationRepeatForever, pulse.RepeatCount)
+		require.True(t, pulse.AutoReverse)
+		fade := doc.Animations["fade"]
+		require.Equal(t, 2*time.Second, fade.Duration)
+		require.Equal(t, 3, fade.RepeatCount)
+
+		objs := doc.Roots["root"].(*fyne.Container).Objects
+		rect := objs[0].(*canvas.Rectangle)
+		require.Equal(t, color.NRGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}, rect.FillColor)
+		require.Equal(t, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, rect.StrokeColor)
+		require.Equal(t, float32(2), rect.StrokeWidth)
+		require.Equal(t, fyne.NewSize(16, 16), rect.MinSize())
+
+		// The test driver runs animations straight to their end.
+		label := objs[1].(*widget.Label)
+		doc.StartAnimations()
+		require.Equal(t, fyne.NewSize(30, 40), label.Size())
+		doc.Animations["slide"].Start()
+		require.Equal(t, fyne.NewPos(10, 20), label.Position())
+
+		objs[2].(*widget.Button).OnTapped()
+		require.Equal(t, color.NRGBA{G: 0xff, A: 0xff}, rect.FillColor)
+		fade.Start()
+		require.Equal(t, color.NRGBA{G: 0xff, A: 0x7f}, rect.FillColor)
+		fade.Start()
+		require.Equal(t, color.NRGBA{G: 0xff, A: 0x7f}, rect.FillColor)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+animations:
+  a: {target: text, property: color, to: "#fff"}
+  b: {target: missing, property: size, to: {width: 1, height: 1}}
+  c: {target: text, property: spin, to: 1}
+  d: {target: text, property: opacity, to: 2}
+  e: {target: text, property: position, duration: soon, to: {x: 1, y: 1}}
+root:
+  type: vbox
+  children:
+  - {type: label, id: text}
+  - {type: button, func: [{start-animation: z}]}
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		out := sb.String()
+		require.Equal(t, 6, ctx.ErrorCount(), out)
+		require.Contains(t, out, "animations.a.target: 3:7: colors of *widget.Label may not be animated")
+		require.Contains(t, out, "animations.b.target: 4:7: unknown target \"missing\"")
+		require.Contains(t, out, "animations.c.property: ")
+		require.Contains(t, out, "animations.d.to: 6:40: opacity must be between 0 and 1")
+		require.Contains(t, out, "animations.e.duration: ")
+		require.Contains(t, out, "root.children[1].func[0].start-animation: 12:28: no animation \"z\" defined")
+	})
+	t.Run("FadeInOut", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+animations:
+  out: {target: light, property: opacity, to: 0}
+  in: {target: light, property: opacity, from: 0, to: 1}
+root: {type: rectangle, id: light, color: "#ff0000"}
+`
+		doc, err := fyneloader.New().LoadYAML(errctx.New(), strings.NewReader(def))
+		require.NoError(t, err)
+		rect := doc.Roots["root"].(*canvas.Rectangle)
+		for i := 0; i < 2; i++ {
+			doc.Animations["out"].Start()
+			require.Equal(t, color.NRGBA{R: 0xff}, rect.FillColor)
+			doc.Animations["in"].Start()
+			require.Equal(t, color.NRGBA{R: 0xff, A: 0xff}, rect.FillColor)
+		}
+	})
+}
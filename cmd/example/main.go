@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -22,6 +24,7 @@ type App struct {
 	OutFp io.Writer
 	ErrFp io.Writer
 
+	args        []string
 	files       []string
 	roots       map[string]map[string]fyne.CanvasObject
 	exit        int
@@ -32,6 +35,8 @@ type App struct {
 	currentfile string
 	currentroot string
 	viewsmenu   *fyne.Menu
+	filemenu    *fyne.Menu
+	mainmenu    *fyne.MainMenu
 }
 
 func NewApp(name string, args []string, outfp, errfp io.Writer) *App {
@@ -46,7 +51,7 @@ func NewApp(name string, args []string, outfp, errfp io.Writer) *App {
 		OutFp: outfp,
 		ErrFp: errfp,
 
-		files:     args,
+		args:      args,
 		roots:     map[string]map[string]fyne.CanvasObject{},
 		loader:    fyneloader.New(),
 		ctx:       errctx.New(&errctx.ErrorPrinter{Stream: errfp}),
@@ -55,13 +60,26 @@ func NewApp(name string, args []string, outfp, errfp io.Writer) *App {
 }
 
 func (a *App) load() error {
+	a.mainmenu = nil
 	for _, f := range a.files {
 		fmt.Fprintf(a.OutFp, "Loading %s\n", f)
-		roots, err := a.loader.ReadFile(a.ctx, f)
-		if err != nil {
+		doc, err := a.loader.LoadFile(a.ctx, f)
+		var lerr fyneloader.LoadError
+		if errors.As(err, &lerr) && doc != nil {
+			fmt.Fprintf(a.ErrFp, "%s: %d errors\n", f, len(lerr.Errors))
+		} else if err != nil {
 			return err
 		}
+		roots := doc.Roots
 		a.roots[f] = roots
+		if doc.Theme != nil {
+			a.app.Settings().SetTheme(doc.Theme)
+		}
+		if menu, ok := doc.MainMenus["main"]; ok && a.mainmenu == nil {
+			a.mainmenu = menu
+		}
+		doc.AddShortcuts(a.window.Canvas())
+		doc.StartAnimations()
 
 		if a.currentroot != "" {
 			continue
@@ -96,6 +114,7 @@ func (a *App) load() error {
 		}
 		a.viewsmenu.Items = views
 	}
+	a.setmenu()
 
 	if a.currentfile != "" {
 		fmt.Fprintf(a.OutFp, "Setting current content to %s %s\n", a.currentfile, a.currentroot)
@@ -108,6 +127,24 @@ func (a *App) load() error {
 	return nil
 }
 
+// setmenu sets the main menu of the window to the main menu named "main" in
+// the loaded files, or the default File menu if there is none, followed by the
+// Views menu.
+func (a *App) setmenu() {
+	menus := []*fyne.Menu{a.filemenu}
+	if a.mainmenu != nil {
+		menus = append([]*fyne.Menu{}, a.mainmenu.Items...)
+	}
+	a.window.SetMainMenu(fyne.NewMainMenu(append(menus, a.viewsmenu)...))
+}
+
+func (a *App) reload() {
+	err := a.load()
+	if err != nil {
+		fmt.Fprintf(a.ErrFp, "Error: %v\n", err)
+	}
+}
+
 func (a *App) newviewitem(file, root string) *fyne.MenuItem {
 	return fyne.NewMenuItem(root, func() {
 		if a.currentfile == file && a.currentroot == root {
@@ -132,6 +169,32 @@ func (a *App) makeviewmenu(filename string, roots map[string]fyne.CanvasObject)
 }
 
 func (a *App) Run() int {
+	flags := flag.NewFlagSet(a.Name, flag.ContinueOnError)
+	flags.SetOutput(a.ErrFp)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [-schema] [-check json|sarif] FILES...\n", a.Name)
+		flags.PrintDefaults()
+	}
+	schema := flags.Bool("schema", false, "write the JSON schema of definition files and exit")
+	check := flags.String("check", "", "check the files without showing them, writing diagnostics as `format` (json or sarif)")
+	if err := flags.Parse(a.args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	a.files = flags.Args()
+
+	if *schema {
+		if err := a.loader.WriteSchema(a.OutFp); err != nil {
+			fmt.Fprintf(a.ErrFp, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if *check != "" {
+		return a.check(*check, a.files)
+	}
 	if len(a.files) == 0 {
 		return 0
 	}
@@ -139,32 +202,34 @@ func (a *App) Run() int {
 	a.exit = 0
 	a.app = app.New()
 	a.window = a.app.NewWindow("FyneLoader Example")
+	a.loader.OnNavigate = func(root string, content fyne.CanvasObject) {
+		for file, roots := range a.roots {
+			if roots[root] == content {
+				a.currentfile = file
+			}
+		}
+		a.currentroot = root
+		content.Show()
+		a.window.SetContent(content)
+	}
 	reload := desktop.CustomShortcut{KeyName: fyne.KeyR, Modifier: desktop.ControlModifier}
 	a.window.Canvas().AddShortcut(&reload, func(shortcut fyne.Shortcut) {
-		err := a.load()
-		if err != nil {
-			fmt.Fprintf(a.ErrFp, "Error: %v\n", err)
-		}
+		a.reload()
 	})
 
-	menu := fyne.NewMainMenu(
-		&fyne.Menu{
-			Label: "File",
-			Items: []*fyne.MenuItem{
-				{Label: "Reload", Action: func() {
-					err := a.load()
-					if err != nil {
-						fmt.Fprintf(a.ErrFp, "Error: %v\n", err)
-					}
-				}},
-				{Label: "Quit", Action: func() {
-					a.window.Close()
-				}},
-			},
+	_ = a.loader.RegisterFunc("reload", a.reload)
+	_ = a.loader.RegisterFunc("quit", func() {
+		a.window.Close()
+	})
+	a.filemenu = &fyne.Menu{
+		Label: "File",
+		Items: []*fyne.MenuItem{
+			{Label: "Reload", Action: a.reload},
+			{Label: "Quit", Action: func() {
+				a.window.Close()
+			}},
 		},
-		a.viewsmenu,
-	)
-	a.window.SetMainMenu(menu)
+	}
 
 	err := a.load()
 	if err != nil {
@@ -175,3 +240,37 @@ func (a *App) Run() int {
 	a.window.ShowAndRun()
 	return 0
 }
+
+// check loads the given files without showing them, writing the diagnostics
+// to the output in the given format; it returns 1 if any file has errors.
+func (a *App) check(format string, files []string) int {
+	var sarif *fyneloader.SARIFHandler
+	switch format {
+	case "json":
+		a.ctx.Handler = &fyneloader.JSONHandler{Stream: a.OutFp}
+	case "sarif":
+		sarif = &fyneloader.SARIFHandler{}
+		a.ctx.Handler = sarif
+	default:
+		fmt.Fprintf(a.ErrFp, "Error: unknown diagnostic format %q\n", format)
+		return 1
+	}
+
+	exit := 0
+	for _, f := range files {
+		if _, err := a.loader.LoadFile(a.ctx, f); err != nil {
+			var lerr fyneloader.LoadError
+			if !errors.As(err, &lerr) {
+				fmt.Fprintf(a.ErrFp, "Error: %v\n", err)
+			}
+			exit = 1
+		}
+	}
+	if sarif != nil {
+		if err := sarif.WriteLog(a.OutFp); err != nil {
+			fmt.Fprintf(a.ErrFp, "Error: %v\n", err)
+			return 1
+		}
+	}
+	return exit
+}
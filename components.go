@@ -0,0 +1,200 @@
+package fyneloader
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// component is a reusable element definition read from the components section
+// of a definition file.
+type component struct {
+	params  map[string]componentParam
+	content interface{}
+}
+
+// componentParam describes a single parameter of a component.
+type componentParam struct {
+	value    interface{}
+	required bool
+}
+
+// readComponents reads the components section of a definition file.
+func (l *Loader) readComponents(ctx *errctx.Context, raw interface{}) {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	for name, v := range data {
+		ctx.Path.Add(mpath.Key(name))
+		c := l.readComponent(ctx, name, v)
+		ctx.Path.Pop()
+		if c != nil {
+			l.components[name] = c
+		}
+	}
+}
+
+func (l *Loader) readComponent(ctx *errctx.Context, name string, raw interface{}) *component {
+	if _, ok := l.elements[name]; ok || name == ValueSlot || name == ValueRepeat {
+		ctx.Error(ComponentNameError{Name: name})
+		return nil
+	}
+
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	content, ok := data[KeyContent]
+	if !ok || content == nil {
+		ctx.Error(maputil.MissingRequiredValueError{Key: KeyContent})
+		return nil
+	}
+
+	c := &component{
+		params:  map[string]componentParam{},
+		content: content,
+	}
+	params := unpack.OptionalObject(ctx, data, KeyParams, nil)
+	ctx.Path.Add(mpath.Key(KeyParams))
+	for pname, v := range params {
+		switch pname {
+		case KeyType, KeyID, KeyIf, KeyClass, KeyChild, KeyChildren:
+			ctx.Error(ComponentParamError{Component: name, Name: pname})
+			continue
+		}
+		if v == nil {
+			c.params[pname] = componentParam{}
+			continue
+		}
+
+		spec, err := maputil.AsObject(v)
+		if err != nil {
+			ctx.ErrorWithKey(err, pname)
+			continue
+		}
+		ctx.Path.Add(mpath.Key(pname))
+		c.params[pname] = componentParam{
+			value:    spec[KeyDefault],
+			required: unpack.OptionalBoolean(ctx, spec, KeyRequired, false),
+		}
+		ctx.Path.Pop()
+	}
+	ctx.Path.Pop()
+	return c
+}
+
+// unpackComponent expands a component with the arguments given in use and
+// unpacks the result. Defaults of parameters which aren't given are expanded
+// in the scope the component is used in.
+func (l *Loader) unpackComponent(
+	ctx *errctx.Context, name string, c *component, use map[string]interface{},
+) fyne.CanvasObject {
+	for _, n := range l.expanding {
+		if n == name {
+			ctx.Error(RecursiveComponentError{Name: name})
+			return nil
+		}
+	}
+	l.expanding = append(l.expanding, name)
+	defer func() {
+		l.expanding = l.expanding[:len(l.expanding)-1]
+	}()
+
+	for k := range use {
+		switch k {
+		case KeyType, KeyID, KeyIf, KeyClass, KeyChild, KeyChildren:
+			continue
+		}
+		if _, ok := c.params[k]; !ok {
+			ctx.ErrorWithKey(ComponentParamError{Component: name, Name: k}, k)
+		}
+	}
+
+	args := make(map[string]interface{}, len(c.params))
+	for pname, p := range c.params {
+		if v, ok := use[pname]; ok {
+			args[pname] = v
+			continue
+		}
+		if p.required {
+			ctx.Error(maputil.MissingRequiredValueError{Key: pname})
+			return nil
+		}
+		args[pname] = l.substitute(ctx, p.value, l.lookup)
+	}
+
+	l.scopes = append(l.scopes, args)
+	defer func() {
+		l.scopes = l.scopes[:len(l.scopes)-1]
+	}()
+
+	content := l.substitute(ctx, c.content, l.lookup)
+	content = fillSlots(ctx, name, content, use[KeyChild], use[KeyChildren])
+	content = addClasses(content, use[KeyClass])
+	return l.Unpack(ctx, content)
+}
+
+// fillSlots replaces slot placeholders in v with the child elements given by
+// the user of a component.
+//
+// A slot within an array is replaced by all of the given children, while a
+// slot anywhere else is replaced by the single given child; giving several
+// children to such a slot is an error.
+func fillSlots(ctx *errctx.Context, name string, v, child, children interface{}) interface{} {
+	if isSlot(v) {
+		if child == nil {
+			if a, ok := children.([]interface{}); ok {
+				if len(a) > 1 {
+					ctx.Error(SlotChildrenError{Component: name, Count: len(a)})
+				} else if len(a) == 1 {
+					return a[0]
+				}
+			}
+		}
+		return child
+	}
+
+	switch d := v.(type) {
+	case map[string]interface{}:
+		for k, value := range d {
+			ctx.Path.Add(mpath.Key(k))
+			d[k] = fillSlots(ctx, name, value, child, children)
+			ctx.Path.Pop()
+		}
+	case []interface{}:
+		a := make([]interface{}, 0, len(d))
+		for _, value := range d {
+			if !isSlot(value) {
+				ctx.Path.Add(mpath.Index(len(a)))
+				a = append(a, fillSlots(ctx, name, value, child, children))
+				ctx.Path.Pop()
+				continue
+			}
+			if c, ok := children.([]interface{}); ok {
+				a = append(a, c...)
+			} else if child != nil {
+				a = append(a, child)
+			}
+		}
+		return a
+	}
+	return v
+}
+
+func isSlot(v interface{}) bool {
+	switch d := v.(type) {
+	case string:
+		return d == ValueSlot
+	case map[string]interface{}:
+		typename, _ := d[KeyType].(string)
+		return typename == ValueSlot
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+const componentDefs = `
+components:
+  labeled-field:
+    params:
+      label:
+        required: true
+      suffix:
+        default: ":"
+    content:
+      type: hbox
+      children:
+      - type: label
+        text: "${label}${suffix}"
+      - slot
+`
+
+func TestComponents(t *testing.T) {
+	t.Parallel()
+	t.Run("Expand", func(t *testing.T) {
+		t.Parallel()
+		const doc = componentDefs + `
+root:
+  type: labeled-field
+  label: Name
+  children:
+  - type: label
+    text: one
+  - type: label
+    text: two
+`
+		ctx := errctx.New()
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Len(t, roots, 1)
+
+		box, ok := roots["root"].(*fyne.Container)
+		require.True(t, ok)
+		require.Len(t, box.Objects, 3)
+		require.Equal(t, "Name:", box.Objects[0].(*widget.Label).Text)
+		require.Equal(t, "one", box.Objects[1].(*widget.Label).Text)
+		require.Equal(t, "two", box.Objects[2].(*widget.Label).Text)
+	})
+	t.Run("InterpolatedDefault", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+vars:
+  greeting: Hello
+components:
+  greet:
+    params:
+      name:
+        default: "${greeting}"
+    content: {type: label, text: "${name}!"}
+  current:
+    params:
+      name: {default: "${item}"}
+    content: {type: label, text: "${name}"}
+root:
+  type: vbox
+  children:
+  - greet
+  - {type: greet, name: Bye}
+  - type: repeat
+    items: [one]
+    template: current
+  - type: repeat
+    items: [two]
+    template:
+      type: greet
+      name: "${item}"
+`
+		ctx := errctx.New()
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		var texts []string
+		for _, obj := range roots["root"].(*fyne.Container).Objects {
+			texts = append(texts, obj.(*widget.Label).Text)
+		}
+		require.Equal(t, []string{"Hello!", "Bye!", "one", "two!"}, texts)
+	})
+	t.Run("MissingRequiredParam", func(t *testing.T) {
+		t.Parallel()
+		const doc = componentDefs + `
+root:
+  type: labeled-field
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Empty(t, roots)
+		require.Equal(t, "root: 16:1: missing required value \"label\"\n", sb.String())
+	})
+	t.Run("UnknownParam", func(t *testing.T) {
+		t.Parallel()
+		const doc = componentDefs + `
+root:
+  type: labeled-field
+  label: Name
+  lable: Name
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t, "root.lable: 19:3: invalid parameter \"lable\" for component \"labeled-field\"\n",
+			sb.String(),
+		)
+	})
+	t.Run("Recursive", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+components:
+  loop:
+    content:
+      type: vbox
+      children:
+      - loop
+root: loop
+`
+		ctx := errctx.New()
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, 1, ctx.ErrorCount())
+		require.Equal(t, fyneloader.RecursiveComponentError{Name: "loop"}, ctx.LastError())
+	})
+	t.Run("SingleSlot", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+components:
+  framed:
+    content:
+      type: card
+      child: slot
+first:
+  type: framed
+  children: [{type: label, text: one}]
+second:
+  type: framed
+  children: [label, label]
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t, "second.child: 10:1: component \"framed\" has a slot for one child but was given 2\n", sb.String(),
+		)
+		require.Equal(t, "FL133", fyneloader.ErrorCode(ctx.LastError()))
+		require.Equal(t, "one", roots["first"].(*widget.Card).Content.(*widget.Label).Text)
+	})
+}
@@ -1,9 +1,14 @@
 package fyneloader
 
 import (
+	"image/color"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 	"github.com/tvarney/maputil"
 	"github.com/tvarney/maputil/errctx"
@@ -31,7 +36,7 @@ func CreateAccordion(ctx *errctx.Context, l *Loader, data map[string]interface{}
 
 			ctx.Path.Add(mpath.Index(i))
 
-			item := widget.NewAccordionItem(unpack.OptionalString(ctx, data, KeyTitle, ""), l.GetChild(ctx, data))
+			item := widget.NewAccordionItem(unpack.OptionalString(ctx, raw, KeyTitle, ""), l.GetChild(ctx, raw))
 			item.Open = unpack.OptionalBoolean(ctx, raw, KeyOpen, false)
 			items = append(items, item)
 
@@ -41,6 +46,7 @@ func CreateAccordion(ctx *errctx.Context, l *Loader, data map[string]interface{}
 
 	a := widget.NewAccordion(items...)
 	a.MultiOpen = unpack.OptionalBoolean(ctx, data, KeyMultiOpen, false)
+	a.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
 
 	return a
 }
@@ -51,10 +57,7 @@ func CreateButton(ctx *errctx.Context, l *Loader, data map[string]interface{}) f
 		return widget.NewButton("", nil)
 	}
 
-	fn, err := GetFnVoidToVoid(l, data, KeyFunc)
-	ctx.ErrorWithKey(err, KeyFunc)
-
-	btn := widget.NewButton(unpack.OptionalString(ctx, data, KeyText, ""), fn)
+	btn := widget.NewButton(unpack.OptionalString(ctx, data, KeyText, ""), l.getFnVoidToVoid(ctx, data, KeyFunc))
 	btn.Alignment = GetButtonAlign(ctx, data)
 	btn.IconPlacement = GetButtonIconPlacement(ctx, data)
 	btn.Importance = GetButtonImportance(ctx, data)
@@ -87,17 +90,86 @@ func CreateCheck(ctx *errctx.Context, l *Loader, data map[string]interface{}) fy
 		return widget.NewCheck("", nil)
 	}
 
-	fn, err := GetFnBoolToVoid(l, data, KeyFunc)
-	ctx.ErrorWithKey(err, KeyFunc)
-
-	check := widget.NewCheck(unpack.OptionalString(ctx, data, KeyText, ""), fn)
+	check := widget.NewCheck(unpack.OptionalString(ctx, data, KeyText, ""), l.getFnBoolToVoid(ctx, data, KeyFunc))
 	check.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
 	if unpack.OptionalBoolean(ctx, data, KeyDisabled, false) {
 		check.Disable()
 	}
+	l.BindPreference(ctx, data, check)
 	return check
 }
 
+// CreateEntry creates a new Entry widget.
+func CreateEntry(ctx *errctx.Context, l *Loader, data map[string]interface{}) fyne.CanvasObject {
+	if data == nil {
+		return widget.NewEntry()
+	}
+
+	var entry *widget.Entry
+	switch {
+	case unpack.OptionalBoolean(ctx, data, KeyPassword, false):
+		entry = widget.NewPasswordEntry()
+	case unpack.OptionalBoolean(ctx, data, KeyMultiLine, false):
+		entry = widget.NewMultiLineEntry()
+	default:
+		entry = widget.NewEntry()
+	}
+	entry.Text = unpack.OptionalString(ctx, data, KeyText, "")
+	entry.PlaceHolder = unpack.OptionalString(ctx, data, KeyPlaceHolder, "")
+	entry.Wrapping = GetTextWrap(ctx, data)
+	entry.OnChanged = l.getFnStringToVoid(ctx, data, KeyFunc)
+	entry.OnSubmitted = l.getFnStringToVoid(ctx, data, KeyOnSubmit)
+	entry.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
+	if unpack.OptionalBoolean(ctx, data, KeyDisabled, false) {
+		entry.Disable()
+	}
+	l.BindPreference(ctx, data, entry)
+	return entry
+}
+
+// CreateFilePicker creates a new FilePicker widget.
+func CreateFilePicker(ctx *errctx.Context, l *Loader, data map[string]interface{}) fyne.CanvasObject {
+	if data == nil {
+		return NewFilePicker(ValueOpen, nil)
+	}
+
+	picker := NewFilePicker(
+		unpack.OptionalStringEnum(ctx, data, KeyMode, filePickerModes, ValueOpen),
+		l.getFnURIToVoid(ctx, data, KeyFunc),
+	)
+	picker.Filter = getExtensions(ctx, data, KeyFilter)
+	if name := unpack.OptionalString(ctx, data, KeyBind, ""); name != "" {
+		switch b := l.bindings[name].(type) {
+		case binding.URI:
+			picker.Bind(b)
+		case binding.String:
+			picker.BindPath(b)
+		case nil:
+			ctx.ErrorWithKey(UndefinedBindingError{Name: name}, KeyBind)
+		default:
+			ctx.ErrorWithKey(BindingTypeError{Name: name, Binding: b}, KeyBind)
+		}
+	}
+	picker.FileName = unpack.OptionalString(ctx, data, KeyFileName, "")
+	if location := unpack.OptionalString(ctx, data, KeyLocation, ""); location != "" {
+		lister, err := storage.ListerForURI(storage.NewFileURI(location))
+		if err != nil {
+			ctx.ErrorWithKey(err, KeyLocation)
+		} else {
+			picker.Location = lister
+		}
+	}
+	if text := unpack.OptionalString(ctx, data, KeyText, ""); text != "" {
+		picker.SetText(text)
+	}
+	picker.SetPlaceHolder(unpack.OptionalString(ctx, data, KeyPlaceHolder, ""))
+	picker.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
+	if unpack.OptionalBoolean(ctx, data, KeyDisabled, false) {
+		picker.Disable()
+	}
+	return picker
+}
+
 // CreateHBox creates a new HBox container.
 func CreateHBox(ctx *errctx.Context, l *Loader, data map[string]interface{}) fyne.CanvasObject {
 	return createBox(ctx, l, data, container.NewHBox)
@@ -118,6 +190,7 @@ func CreateLabel(ctx *errctx.Context, l *Loader, data map[string]interface{}) fy
 	label.Alignment = GetTextAlign(ctx, data)
 	label.Wrapping = GetTextWrap(ctx, data)
 	label.TextStyle = GetTextStyle(ctx, data, KeyStyle)
+	label.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
 	return label
 }
 
@@ -127,9 +200,9 @@ func CreateRadioGroup(ctx *errctx.Context, l *Loader, data map[string]interface{
 		return widget.NewRadioGroup(nil, nil)
 	}
 
-	fn, err := GetFnStringToVoid(l, data, KeyFunc)
-	ctx.ErrorWithKey(err, KeyFunc)
-	rgroup := widget.NewRadioGroup(unpack.OptionalStringArray(ctx, data, KeyOptions), fn)
+	rgroup := widget.NewRadioGroup(
+		unpack.OptionalStringArray(ctx, data, KeyOptions), l.getFnStringToVoid(ctx, data, KeyFunc),
+	)
 	rgroup.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
 	rgroup.Required = unpack.OptionalBoolean(ctx, data, KeyRequired, false)
 	rgroup.Selected = unpack.OptionalString(ctx, data, KeySelected, "")
@@ -137,9 +210,52 @@ func CreateRadioGroup(ctx *errctx.Context, l *Loader, data map[string]interface{
 	if unpack.OptionalBoolean(ctx, data, KeyDisabled, false) {
 		rgroup.Disable()
 	}
+	l.BindPreference(ctx, data, rgroup)
 	return rgroup
 }
 
+// CreateRectangle creates a new Rectangle.
+func CreateRectangle(ctx *errctx.Context, l *Loader, data map[string]interface{}) fyne.CanvasObject {
+	if data == nil {
+		return canvas.NewRectangle(color.Transparent)
+	}
+
+	rect := canvas.NewRectangle(GetColor(ctx, data, KeyColor, color.Transparent))
+	rect.StrokeColor = GetColor(ctx, data, KeyStrokeColor, color.Transparent)
+	rect.StrokeWidth = float32(unpack.OptionalNumber(ctx, data, KeyStrokeWidth, 0.0))
+	if size := unpack.OptionalObject(ctx, data, KeyMinSize, nil); size != nil {
+		ctx.Path.Add(mpath.Key(KeyMinSize))
+		rect.SetMinSize(fyne.NewSize(
+			float32(unpack.RequireNumber(ctx, size, KeyWidth)),
+			float32(unpack.RequireNumber(ctx, size, KeyHeight)),
+		))
+		ctx.Path.Pop()
+	}
+	rect.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
+	return rect
+}
+
+// CreateSelect creates a new Select widget.
+func CreateSelect(ctx *errctx.Context, l *Loader, data map[string]interface{}) fyne.CanvasObject {
+	if data == nil {
+		return widget.NewSelect(nil, nil)
+	}
+
+	sel := widget.NewSelect(
+		unpack.OptionalStringArray(ctx, data, KeyOptions), l.getFnStringToVoid(ctx, data, KeyFunc),
+	)
+	sel.Selected = unpack.OptionalString(ctx, data, KeySelected, "")
+	if placeholder := unpack.OptionalString(ctx, data, KeyPlaceHolder, ""); placeholder != "" {
+		sel.PlaceHolder = placeholder
+	}
+	sel.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
+	if unpack.OptionalBoolean(ctx, data, KeyDisabled, false) {
+		sel.Disable()
+	}
+	l.BindPreference(ctx, data, sel)
+	return sel
+}
+
 // CreateSpacer creates a new spacer which expands both vertically and
 // horizontally.
 func CreateSpacer(ctx *errctx.Context, l *Loader, data map[string]interface{}) fyne.CanvasObject {
@@ -152,17 +268,15 @@ func CreateSlider(ctx *errctx.Context, l *Loader, data map[string]interface{}) f
 		return widget.NewSlider(0.0, 100.0)
 	}
 
-	fn, err := GetFnFloat64ToVoid(l, data, KeyFunc)
-	ctx.ErrorWithKey(err, KeyFunc)
-
 	slider := widget.NewSlider(
 		unpack.OptionalNumber(ctx, data, KeyMin, 0.0),
 		unpack.OptionalNumber(ctx, data, KeyMax, 100.0),
 	)
 	slider.Step = unpack.OptionalNumber(ctx, data, KeyStep, 1.0)
-	slider.OnChanged = fn
+	slider.OnChanged = l.getFnFloat64ToVoid(ctx, data, KeyFunc)
 	slider.Orientation = GetOrientation(ctx, data, widget.Horizontal)
 	slider.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
+	l.BindPreference(ctx, data, slider)
 	return slider
 }
 
@@ -183,20 +297,7 @@ func createBox(
 	if data == nil {
 		return fn()
 	}
-	raw := unpack.OptionalArray(ctx, data, KeyChildren, nil)
-	var children []fyne.CanvasObject
-	if len(raw) > 0 {
-		children = make([]fyne.CanvasObject, 0, len(raw))
-		for i, c := range raw {
-			ctx.Path.Add(mpath.Index(i))
-			child := l.Unpack(ctx, c)
-			ctx.Path.Pop()
-			if child != nil {
-				children = append(children, child)
-			}
-		}
-	}
-	box := fn(children...)
+	box := fn(l.GetChildren(ctx, data)...)
 	box.Hidden = unpack.OptionalBoolean(ctx, data, KeyHidden, false)
 	return box
 }
@@ -0,0 +1,62 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestConstructors(t *testing.T) {
+	t.Parallel()
+	t.Run("Accordion", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+root:
+  type: accordion
+  hidden: true
+  items:
+  - title: One
+    child: {type: label, text: first}
+  - title: Two
+    open: true
+    child: {type: button, text: second}
+`
+		ctx := errctx.New()
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		acc := roots["root"].(*widget.Accordion)
+		require.False(t, acc.Visible())
+		require.Len(t, acc.Items, 2)
+		require.Equal(t, "One", acc.Items[0].Title)
+		require.False(t, acc.Items[0].Open)
+		require.Equal(t, "first", acc.Items[0].Detail.(*widget.Label).Text)
+		require.Equal(t, "Two", acc.Items[1].Title)
+		require.True(t, acc.Items[1].Open)
+		require.Equal(t, "second", acc.Items[1].Detail.(*widget.Button).Text)
+	})
+	t.Run("HiddenLabel", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+root:
+  type: vbox
+  children:
+  - {type: label, text: shown}
+  - {type: label, text: hidden, hidden: true}
+`
+		ctx := errctx.New()
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		box := roots["root"].(*fyne.Container)
+		require.True(t, box.Objects[0].Visible())
+		require.False(t, box.Objects[1].Visible())
+	})
+}
@@ -0,0 +1,340 @@
+package fyneloader
+
+import "sort"
+
+// ValueType names the kind of value accepted by a key of an element.
+type ValueType string
+
+// The kinds of values accepted by the keys of elements.
+const (
+	TypeAny         ValueType = "any"
+	TypeBoolean     ValueType = "boolean"
+	TypeColor       ValueType = "color"
+	TypeElement     ValueType = "element"
+	TypeElements    ValueType = "elements"
+	TypeFunc        ValueType = "func"
+	TypeInteger     ValueType = "integer"
+	TypeNumber      ValueType = "number"
+	TypeObject      ValueType = "object"
+	TypeObjectArray ValueType = "object-array"
+	TypeString      ValueType = "string"
+	TypeStringArray ValueType = "string-array"
+)
+
+// KeyDescriptor describes a key accepted by an element type.
+type KeyDescriptor struct {
+	Name        string
+	Type        ValueType
+	Description string
+
+	// Enum lists the values accepted by a string key, if it only accepts a
+	// fixed set of values.
+	Enum []string
+
+	// Default is the value used if the key is not given, or nil if there is
+	// no default.
+	Default interface{}
+
+	Required bool
+
+	// Keys describes the keys of an object value, or of each object of an
+	// object array value.
+	Keys []KeyDescriptor
+
+	// Schema is a JSON Schema fragment used for the value of the key in place
+	// of the one generated from the descriptor.
+	Schema map[string]interface{}
+}
+
+// ElementDescriptor describes the keys accepted by an element type, for use by
+// editors, linters and documentation.
+//
+// The type, id, if and class keys accepted by every element are not included;
+// they are described by CommonKeys.
+type ElementDescriptor struct {
+	Description string
+	Keys        []KeyDescriptor
+
+	// Schema is a JSON Schema fragment used for elements of the type in place
+	// of the one generated from the descriptor.
+	Schema map[string]interface{}
+}
+
+// Key returns the descriptor of the key with the given name.
+func (d *ElementDescriptor) Key(name string) (KeyDescriptor, bool) {
+	for _, k := range d.Keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return KeyDescriptor{}, false
+}
+
+// Slots returns the keys which hold child elements.
+//
+// Keys nested within object values are given as dot separated paths, such as
+// `items.child` for the children of accordion items.
+func (d *ElementDescriptor) Slots() []string {
+	return appendSlots(nil, "", d.Keys)
+}
+
+func appendSlots(slots []string, prefix string, keys []KeyDescriptor) []string {
+	for _, k := range keys {
+		switch k.Type {
+		case TypeElement, TypeElements:
+			slots = append(slots, prefix+k.Name)
+		case TypeObject, TypeObjectArray:
+			slots = appendSlots(slots, prefix+k.Name+".", k.Keys)
+		}
+	}
+	return slots
+}
+
+// CommonKeys returns the descriptors of the keys accepted by every element.
+func CommonKeys() []KeyDescriptor {
+	return []KeyDescriptor{
+		{Name: KeyType, Type: TypeString, Required: true, Description: "The type of the element."},
+		{Name: KeyID, Type: TypeString, Description: "The ID used to target the element from actions."},
+		{
+			Name: KeyIf, Type: TypeAny, Description: "A condition which must hold for the element to be created.",
+			Schema: map[string]interface{}{"type": []string{"string", "boolean"}},
+		},
+		{
+			Name: KeyClass, Type: TypeAny, Description: "The style classes applied to the element.",
+			Schema: map[string]interface{}{"anyOf": []interface{}{
+				map[string]interface{}{"type": "string"},
+				map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			}},
+		},
+	}
+}
+
+// SetElementDescriptor sets the descriptor of an element type.
+//
+// If the descriptor is nil, any existing descriptor for the type is removed.
+func (l *Loader) SetElementDescriptor(name string, desc *ElementDescriptor) {
+	if desc == nil {
+		delete(l.descriptors, name)
+		return
+	}
+	l.descriptors[name] = desc
+}
+
+// GetElementDescriptor returns the descriptor of an element type.
+func (l *Loader) GetElementDescriptor(name string) (*ElementDescriptor, bool) {
+	desc, ok := l.descriptors[name]
+	return desc, ok
+}
+
+// ElementTypes returns the sorted names of the registered element types.
+func (l *Loader) ElementTypes() []string {
+	return sortedKeys(l.elements)
+}
+
+// FuncNames returns the sorted names of the registered functions.
+func (l *Loader) FuncNames() []string {
+	return sortedKeys(l.callbacks)
+}
+
+// ActionNames returns the sorted names of the registered action kinds.
+func (l *Loader) ActionNames() []string {
+	return sortedKeys(l.actions)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Descriptors of keys shared by several of the built-in elements.
+var (
+	keyDisabled   = KeyDescriptor{Name: KeyDisabled, Type: TypeBoolean, Default: false, Description: "Whether the element starts disabled."}
+	keyHidden     = KeyDescriptor{Name: KeyHidden, Type: TypeBoolean, Default: false, Description: "Whether the element starts hidden."}
+	keyOptions    = KeyDescriptor{Name: KeyOptions, Type: TypeStringArray, Description: "The options to choose from."}
+	keyPreference = KeyDescriptor{Name: KeyPreference, Type: TypeString, Description: "The preference the value is persisted to."}
+	keySelected   = KeyDescriptor{Name: KeySelected, Type: TypeString, Description: "The initially selected option."}
+)
+
+func keyFunc(desc string) KeyDescriptor {
+	return KeyDescriptor{Name: KeyFunc, Type: TypeFunc, Description: desc}
+}
+
+func keyPreferenceDefault(t ValueType) KeyDescriptor {
+	return KeyDescriptor{Name: KeyDefault, Type: t, Description: "The value used if the preference is not set."}
+}
+
+func keyEnum(name string, values []string, desc string) KeyDescriptor {
+	return KeyDescriptor{Name: name, Type: TypeString, Enum: values, Default: ValueDefault, Description: desc}
+}
+
+func boxDescriptor(direction string) *ElementDescriptor {
+	return &ElementDescriptor{
+		Description: "A container laying out its children " + direction + ".",
+		Keys: []KeyDescriptor{
+			{Name: KeyChildren, Type: TypeElements, Description: "The elements in the box."},
+			keyHidden,
+		},
+	}
+}
+
+// builtinDescriptors returns the descriptors of the built-in element types.
+func builtinDescriptors() map[string]*ElementDescriptor {
+	return map[string]*ElementDescriptor{
+		"accordion": {
+			Description: "A list of items which may be expanded to show their content.",
+			Keys: []KeyDescriptor{
+				{
+					Name: KeyItems, Type: TypeObjectArray, Description: "The items of the accordion.",
+					Keys: []KeyDescriptor{
+						{Name: KeyTitle, Type: TypeString, Description: "The title of the item."},
+						{Name: KeyChild, Type: TypeElement, Description: "The content of the item."},
+						{Name: KeyOpen, Type: TypeBoolean, Default: false, Description: "Whether the item starts open."},
+					},
+				},
+				{Name: KeyMultiOpen, Type: TypeBoolean, Default: false, Description: "Whether several items may be open at once."},
+				keyHidden,
+			},
+		},
+		"button": {
+			Description: "A button which runs a function when tapped.",
+			Keys: []KeyDescriptor{
+				{Name: KeyText, Type: TypeString, Description: "The text of the button."},
+				keyFunc("The function or actions run when the button is tapped."),
+				keyEnum(KeyAlign, buttonAlignValues, "The alignment of the content of the button."),
+				keyEnum(KeyIconPlace, iconPlacementValues, "The placement of the icon relative to the text."),
+				keyEnum(KeyImportance, importanceValues, "The importance of the button."),
+				keyHidden,
+				keyDisabled,
+			},
+		},
+		"card": {
+			Description: "A card with a title, subtitle, image and content.",
+			Keys: []KeyDescriptor{
+				{Name: KeyTitle, Type: TypeString, Description: "The title of the card."},
+				{Name: KeySubTitle, Type: TypeString, Description: "The subtitle of the card."},
+				{Name: KeyChild, Type: TypeElement, Description: "The content of the card."},
+				{Name: KeyImagePath, Type: TypeString, Description: "The file of the image of the card."},
+				{Name: KeyImageURI, Type: TypeString, Description: "The URI of the image of the card."},
+				keyEnum(KeyImageFill, imageFillValues, "How the image fills its space."),
+				keyHidden,
+			},
+		},
+		"check": {
+			Description: "A check box.",
+			Keys: []KeyDescriptor{
+				{Name: KeyText, Type: TypeString, Description: "The text of the check box."},
+				keyFunc("The function or actions run with the new state when the check box changes."),
+				keyPreference,
+				keyPreferenceDefault(TypeBoolean),
+				keyHidden,
+				keyDisabled,
+			},
+		},
+		"entry": {
+			Description: "A text entry.",
+			Keys: []KeyDescriptor{
+				{Name: KeyText, Type: TypeString, Description: "The initial text."},
+				{Name: KeyPlaceHolder, Type: TypeString, Description: "The text shown while the entry is empty."},
+				{Name: KeyPassword, Type: TypeBoolean, Default: false, Description: "Whether the text is hidden."},
+				{Name: KeyMultiLine, Type: TypeBoolean, Default: false, Description: "Whether the entry accepts several lines."},
+				keyEnum(KeyWrap, textWrapValues, "How the text is wrapped."),
+				keyFunc("The function or actions run with the new text when the text changes."),
+				{Name: KeyOnSubmit, Type: TypeFunc, Description: "The function or actions run with the text when it is submitted."},
+				keyPreference,
+				keyPreferenceDefault(TypeString),
+				keyHidden,
+				keyDisabled,
+			},
+		},
+		"file-picker": {
+			Description: "A button which shows a file dialog, along with the chosen file.",
+			Keys: []KeyDescriptor{
+				{Name: KeyMode, Type: TypeString, Enum: filePickerModes, Default: ValueOpen, Description: "The kind of file dialog shown."},
+				keyFunc("The function or actions run with the URI of the chosen file."),
+				{Name: KeyBind, Type: TypeString, Description: "The name of a registered URI or string binding holding the chosen file."},
+				{Name: KeyFilter, Type: TypeStringArray, Description: "The file extensions which may be chosen."},
+				{Name: KeyFileName, Type: TypeString, Description: "The file name initially given in save dialogs."},
+				{Name: KeyLocation, Type: TypeString, Description: "The directory the dialog starts in."},
+				{Name: KeyText, Type: TypeString, Description: "The text of the button."},
+				{Name: KeyPlaceHolder, Type: TypeString, Description: "The text shown while no file is chosen."},
+				keyHidden,
+				keyDisabled,
+			},
+		},
+		"hbox":    boxDescriptor("horizontally"),
+		"hspacer": {Description: "A spacer which expands horizontally."},
+		"label": {
+			Description: "A text label.",
+			Keys: []KeyDescriptor{
+				{Name: KeyText, Type: TypeString, Description: "The text of the label."},
+				keyEnum(KeyAlign, textAlignValues, "The alignment of the text."),
+				keyEnum(KeyWrap, textWrapValues, "How the text is wrapped."),
+				keyEnum(KeyStyle, textStyleValues, "The style of the text."),
+				keyHidden,
+			},
+		},
+		"radio": {
+			Description: "A group of radio buttons.",
+			Keys: []KeyDescriptor{
+				keyOptions,
+				keySelected,
+				keyFunc("The function or actions run with the selected option when it changes."),
+				{Name: KeyRequired, Type: TypeBoolean, Default: false, Description: "Whether an option must be selected."},
+				keyEnum(KeyOrientation, orientationValues, "The direction the options are laid out in."),
+				keyPreference,
+				keyPreferenceDefault(TypeString),
+				keyHidden,
+				keyDisabled,
+			},
+		},
+		"rectangle": {
+			Description: "A filled rectangle.",
+			Keys: []KeyDescriptor{
+				{Name: KeyColor, Type: TypeColor, Description: "The fill color."},
+				{Name: KeyStrokeColor, Type: TypeColor, Description: "The color of the outline."},
+				{Name: KeyStrokeWidth, Type: TypeNumber, Default: 0.0, Description: "The width of the outline."},
+				{
+					Name: KeyMinSize, Type: TypeObject, Description: "The minimum size of the rectangle.",
+					Keys: []KeyDescriptor{
+						{Name: KeyWidth, Type: TypeNumber, Required: true},
+						{Name: KeyHeight, Type: TypeNumber, Required: true},
+					},
+				},
+				keyHidden,
+			},
+		},
+		"select": {
+			Description: "A drop down list of options.",
+			Keys: []KeyDescriptor{
+				keyOptions,
+				keySelected,
+				{Name: KeyPlaceHolder, Type: TypeString, Description: "The text shown while no option is selected."},
+				keyFunc("The function or actions run with the selected option when it changes."),
+				keyPreference,
+				keyPreferenceDefault(TypeString),
+				keyHidden,
+				keyDisabled,
+			},
+		},
+		"slider": {
+			Description: "A slider choosing a number within a range.",
+			Keys: []KeyDescriptor{
+				{Name: KeyMin, Type: TypeNumber, Default: 0.0, Description: "The lowest value."},
+				{Name: KeyMax, Type: TypeNumber, Default: 100.0, Description: "The highest value."},
+				{Name: KeyStep, Type: TypeNumber, Default: 1.0, Description: "The step between values."},
+				keyFunc("The function or actions run with the new value when it changes."),
+				keyEnum(KeyOrientation, orientationValues, "The direction of the slider."),
+				keyPreference,
+				keyPreferenceDefault(TypeNumber),
+				keyHidden,
+			},
+		},
+		"spacer":  {Description: "A spacer which expands in both directions."},
+		"vbox":    boxDescriptor("vertically"),
+		"vspacer": {Description: "A spacer which expands vertically."},
+	}
+}
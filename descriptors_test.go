@@ -0,0 +1,96 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestDescriptors(t *testing.T) {
+	t.Parallel()
+	t.Run("Builtin", func(t *testing.T) {
+		t.Parallel()
+		l := fyneloader.New()
+		for _, name := range l.ElementTypes() {
+			desc, ok := l.GetElementDescriptor(name)
+			require.True(t, ok, name)
+			require.NotEmpty(t, desc.Description, name)
+		}
+
+		slider, _ := l.GetElementDescriptor("slider")
+		step, ok := slider.Key(fyneloader.KeyStep)
+		require.True(t, ok)
+		require.Equal(t, fyneloader.TypeNumber, step.Type)
+		require.Equal(t, 1.0, step.Default)
+		orientation, _ := slider.Key(fyneloader.KeyOrientation)
+		require.Equal(
+			t, []string{fyneloader.ValueDefault, fyneloader.ValueHorizontal, fyneloader.ValueVertical},
+			orientation.Enum,
+		)
+		_, ok = slider.Key(fyneloader.KeyText)
+		require.False(t, ok)
+
+		vbox, _ := l.GetElementDescriptor("vbox")
+		require.Equal(t, []string{fyneloader.KeyChildren}, vbox.Slots())
+		accordion, _ := l.GetElementDescriptor("accordion")
+		require.Equal(t, []string{"items.child"}, accordion.Slots())
+		label, _ := l.GetElementDescriptor("label")
+		require.Empty(t, label.Slots())
+	})
+	t.Run("Custom", func(t *testing.T) {
+		t.Parallel()
+		l := fyneloader.New()
+		l.RegisterElement("gauge", func(*errctx.Context, *fyneloader.Loader, map[string]interface{}) fyne.CanvasObject {
+			return widget.NewLabel("")
+		})
+		require.Contains(t, l.ElementTypes(), "gauge")
+		_, ok := l.GetElementDescriptor("gauge")
+		require.False(t, ok)
+
+		desc := &fyneloader.ElementDescriptor{
+			Description: "A gauge.",
+			Keys: []fyneloader.KeyDescriptor{
+				{Name: "value", Type: fyneloader.TypeNumber, Required: true},
+			},
+		}
+		l.SetElementDescriptor("gauge", desc)
+		got, ok := l.GetElementDescriptor("gauge")
+		require.True(t, ok)
+		require.Same(t, desc, got)
+
+		l.RegisterElement("gauge", nil)
+		require.NotContains(t, l.ElementTypes(), "gauge")
+		_, ok = l.GetElementDescriptor("gauge")
+		require.False(t, ok)
+	})
+	t.Run("Replace", func(t *testing.T) {
+		t.Parallel()
+		l := fyneloader.New()
+		l.Strict = fyneloader.StrictError
+		l.RegisterElement("slider", func(*errctx.Context, *fyneloader.Loader, map[string]interface{}) fyne.CanvasObject {
+			return widget.NewLabel("")
+		})
+		_, ok := l.GetElementDescriptor("slider")
+		require.False(t, ok)
+		require.NotContains(t, l.Schema()["$defs"], "element-slider")
+
+		ctx := errctx.New()
+		_, err := l.ReadYAML(ctx, strings.NewReader("root: {type: slider, level: 3}"))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+	})
+	t.Run("Names", func(t *testing.T) {
+		t.Parallel()
+		l := fyneloader.New()
+		require.NoError(t, l.RegisterFunc("save", func() {}))
+		require.NoError(t, l.RegisterFunc("quit", func() {}))
+		require.Equal(t, []string{"quit", "save"}, l.FuncNames())
+		require.Contains(t, l.ActionNames(), fyneloader.ActionSetText)
+		require.Equal(t, "accordion", l.ElementTypes()[0])
+	})
+}
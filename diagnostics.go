@@ -0,0 +1,345 @@
+package fyneloader
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/mpath"
+)
+
+// CodeUnknown is the code of errors which are not defined by the loader.
+const CodeUnknown = "FL000"
+
+// errorCodes gives the code and rule name of the errors reported by the
+// loader. Codes are never reused or renumbered; new errors are given the next
+// free code in their group.
+var errorCodes = map[string]string{
+	"FL001": "fetch-uri-disabled",
+	"FL002": "no-preferences",
+	"FL003": "no-widget-type",
+	"FL004": "unknown-file-ext",
+	"FL005": "invalid-option",
+	"FL006": "opacity-range",
+	"FL007": "repeat-outside-children",
+	"FL008": "slot-outside-component",
+	"FL009": "unterminated-reference",
+	"FL010": "no-methods",
+	"FL011": "nil-receiver",
+	"FL100": "action-target",
+	"FL101": "animation-target",
+	"FL102": "array-index-out-of-bounds",
+	"FL103": "component-name",
+	"FL104": "component-param",
+	"FL105": "conflicting-keys",
+	"FL106": "duplicate-id",
+	"FL107": "duplicate-shortcut",
+	"FL108": "expression",
+	"FL109": "function-type",
+	"FL110": "invalid-color",
+	"FL111": "missing-translation",
+	"FL112": "preference-type",
+	"FL113": "recursive-component",
+	"FL114": "shortcut",
+	"FL115": "style-key",
+	"FL116": "undefined-animation",
+	"FL117": "undefined-data",
+	"FL118": "undefined-dialog",
+	"FL119": "undefined-function",
+	"FL120": "undefined-menu",
+	"FL121": "undefined-reference",
+	"FL122": "undefined-style",
+	"FL123": "unknown-identifier",
+	"FL124": "unknown-action",
+	"FL125": "unknown-element",
+	"FL126": "unknown-key",
+	"FL127": "unknown-target",
+	"FL128": "panic",
+	"FL129": "function-signature",
+	"FL130": "duplicate-function",
+	"FL131": "binding-type",
+	"FL132": "undefined-binding",
+	"FL133": "slot-children",
+	"FL134": "duplicate-content",
+	"FL135": "duplicate-master",
+	"FL136": "conversion",
+	"FL200": "invalid-type",
+	"FL201": "invalid-value",
+	"FL202": "missing-required-value",
+}
+
+// ErrorCode returns the stable code of the given error, looking through any
+// wrapping errors; errors which are not defined by the loader have the code
+// CodeUnknown.
+func ErrorCode(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if code := errorCode(err); code != "" {
+			return code
+		}
+	}
+	return CodeUnknown
+}
+
+func errorCode(err error) string {
+	switch e := err.(type) {
+	case ConstError:
+		switch e {
+		case ErrFetchURIDisabled:
+			return "FL001"
+		case ErrNoPreferences:
+			return "FL002"
+		case ErrNoWidgetType:
+			return "FL003"
+		case ErrUnknownFileExt:
+			return "FL004"
+		case ErrInvalidOption:
+			return "FL005"
+		case ErrOpacityRange:
+			return "FL006"
+		case ErrRepeatOutsideChildren:
+			return "FL007"
+		case ErrSlotOutsideComponent:
+			return "FL008"
+		case ErrUnterminatedReference:
+			return "FL009"
+		case ErrNoMethods:
+			return "FL010"
+		case ErrNilReceiver:
+			return "FL011"
+		}
+	case ActionTargetError:
+		return "FL100"
+	case AnimationTargetError:
+		return "FL101"
+	case ArrayIndexOutOfBoundsError:
+		return "FL102"
+	case ComponentNameError:
+		return "FL103"
+	case ComponentParamError:
+		return "FL104"
+	case ConflictingKeysError:
+		return "FL105"
+	case DuplicateIDError:
+		return "FL106"
+	case DuplicateShortcutError:
+		return "FL107"
+	case ExpressionError:
+		return "FL108"
+	case FunctionTypeError:
+		return "FL109"
+	case InvalidColorError:
+		return "FL110"
+	case MissingTranslationError:
+		return "FL111"
+	case PreferenceTypeError:
+		return "FL112"
+	case RecursiveComponentError:
+		return "FL113"
+	case ShortcutError:
+		return "FL114"
+	case StyleKeyError:
+		return "FL115"
+	case UndefinedAnimationError:
+		return "FL116"
+	case UndefinedDataError:
+		return "FL117"
+	case UndefinedDialogError:
+		return "FL118"
+	case UndefinedFunctionError:
+		return "FL119"
+	case UndefinedMenuError:
+		return "FL120"
+	case UndefinedReferenceError:
+		return "FL121"
+	case UndefinedStyleError:
+		return "FL122"
+	case UnknownIdentifierError:
+		return "FL123"
+	case UnknownActionError:
+		return "FL124"
+	case UnknownElementType:
+		return "FL125"
+	case UnknownKeyError:
+		return "FL126"
+	case UnknownTargetError:
+		return "FL127"
+	case PanicError:
+		return "FL128"
+	case FunctionSignatureError:
+		return "FL129"
+	case DuplicateFunctionError:
+		return "FL130"
+	case BindingTypeError:
+		return "FL131"
+	case UndefinedBindingError:
+		return "FL132"
+	case SlotChildrenError:
+		return "FL133"
+	case DuplicateContentError:
+		return "FL134"
+	case DuplicateMasterError:
+		return "FL135"
+	case ConversionError:
+		return "FL136"
+	case maputil.InvalidTypeError:
+		return "FL200"
+	case maputil.EnumStringError:
+		return "FL201"
+	case maputil.MissingRequiredValueError:
+		return "FL202"
+	}
+	return ""
+}
+
+// Diagnostic is the structured form of an error reported while loading a
+// definition.
+type Diagnostic struct {
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Path     string `json:"path"`
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+// NewDiagnostic returns the diagnostic for an error reported at the given
+// path. The message is that of the error without its position or severity.
+func NewDiagnostic(p *mpath.Path, err error) Diagnostic {
+	d := Diagnostic{
+		Path:     p.String(),
+		Severity: SeverityOf(err).String(),
+		Code:     ErrorCode(err),
+	}
+	var perr PositionError
+	if errors.As(err, &perr) {
+		d.File = perr.Position.File
+		d.Line = perr.Position.Line
+		d.Column = perr.Position.Column
+	}
+	for {
+		switch e := err.(type) {
+		case PositionError:
+			err = e.Err
+			continue
+		case Info:
+			err = e.Err
+			continue
+		case Warning:
+			err = e.Err
+			continue
+		}
+		break
+	}
+	d.Message = err.Error()
+	return d
+}
+
+// JSONHandler is an errctx.ErrorHandler which writes each error to a stream as
+// a line of JSON holding its Diagnostic.
+type JSONHandler struct {
+	Stream io.Writer
+}
+
+// Add writes the given error to the stream.
+func (h *JSONHandler) Add(p *mpath.Path, err error) {
+	data, _ := json.Marshal(NewDiagnostic(p, err))
+	_, _ = h.Stream.Write(append(data, '\n'))
+}
+
+// SARIFVersion is the version of the SARIF format written by SARIFHandler.
+const SARIFVersion = "2.1.0"
+
+// SARIFSchema is the schema of the SARIF format written by SARIFHandler.
+const SARIFSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// SARIFHandler is an errctx.ErrorHandler which collects errors so that they
+// may be written as a SARIF log once loading has finished.
+type SARIFHandler struct {
+	Diagnostics []Diagnostic
+}
+
+// Add records the given error.
+func (h *SARIFHandler) Add(p *mpath.Path, err error) {
+	h.Diagnostics = append(h.Diagnostics, NewDiagnostic(p, err))
+}
+
+// WriteLog writes the recorded errors to the given writer as a SARIF log.
+func (h *SARIFHandler) WriteLog(out io.Writer) error {
+	rules := []object{}
+	seen := map[string]bool{}
+	results := []object{}
+	for _, d := range h.Diagnostics {
+		if !seen[d.Code] {
+			seen[d.Code] = true
+			rule := object{"id": d.Code}
+			if name, ok := errorCodes[d.Code]; ok {
+				rule["name"] = name
+			}
+			rules = append(rules, rule)
+		}
+		results = append(results, sarifResult(d))
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i]["id"].(string) < rules[j]["id"].(string)
+	})
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(object{
+		"$schema": SARIFSchema,
+		"version": SARIFVersion,
+		"runs": []object{{
+			"tool": object{
+				"driver": object{
+					"name":           "fyneloader",
+					"informationUri": "https://github.com/tvarney/fyneloader",
+					"rules":          rules,
+				},
+			},
+			"results": results,
+		}},
+	})
+}
+
+// sarifResult returns the SARIF result for a diagnostic.
+func sarifResult(d Diagnostic) object {
+	location := object{
+		"logicalLocations": []object{{"fullyQualifiedName": d.Path}},
+	}
+	if d.File != "" {
+		physical := object{
+			"artifactLocation": object{"uri": sarifURI(d.File)},
+		}
+		if d.Line > 0 {
+			physical["region"] = object{"startLine": d.Line, "startColumn": d.Column}
+		}
+		location["physicalLocation"] = physical
+	}
+	level := "error"
+	switch d.Severity {
+	case SeverityWarning.String():
+		level = "warning"
+	case SeverityInfo.String():
+		level = "note"
+	}
+	return object{
+		"ruleId":    d.Code,
+		"level":     level,
+		"message":   object{"text": d.Message},
+		"locations": []object{location},
+	}
+}
+
+// sarifURI returns the artifact URI of a file; relative paths are kept
+// relative so that they resolve against the root of the repository.
+func sarifURI(file string) string {
+	if filepath.IsAbs(file) {
+		return (&url.URL{Scheme: "file", Path: filepath.ToSlash(file)}).String()
+	}
+	return (&url.URL{Path: filepath.ToSlash(file)}).String()
+}
@@ -0,0 +1,108 @@
+package fyneloader_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	const def = `
+root:
+  type: vbox
+  children:
+  - type: label
+    txt: Hello
+  - type: widget
+`
+
+	t.Run("ErrorCode", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "FL125", fyneloader.ErrorCode(fyneloader.UnknownElementType{TypeName: "x"}))
+		require.Equal(t, "FL009", fyneloader.ErrorCode(fyneloader.ErrUnterminatedReference))
+		require.Equal(t, "FL004", fyneloader.ErrorCode(fmt.Errorf("%w %q", fyneloader.ErrUnknownFileExt, ".txt")))
+		require.Equal(t, "FL126", fyneloader.ErrorCode(fyneloader.PositionError{
+			Err: fyneloader.Warning{Err: fyneloader.UnknownKeyError{Key: "x"}},
+		}))
+		require.Equal(t, "FL200", fyneloader.ErrorCode(maputil.InvalidTypeError{Actual: "array"}))
+		require.Equal(t, fyneloader.CodeUnknown, fyneloader.ErrorCode(errors.New("failed")))
+	})
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		l := fyneloader.New()
+		l.Strict = fyneloader.StrictWarn
+		buf := &bytes.Buffer{}
+		ctx := errctx.New(&fyneloader.JSONHandler{Stream: buf})
+		_, err := l.ReadYAML(ctx, strings.NewReader(def))
+		require.IsType(t, fyneloader.LoadError{}, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		var d fyneloader.Diagnostic
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &d))
+		require.Equal(t, fyneloader.Diagnostic{
+			Line:     6,
+			Column:   5,
+			Path:     "root.children[0].txt",
+			Severity: "warning",
+			Code:     "FL126",
+			Message:  `unknown key "txt", did you mean "text"?`,
+		}, d)
+		require.Equal(
+			t,
+			`{"line":7,"column":5,"path":"root.children[1].type","severity":"error","code":"FL125",`+
+				`"message":"unknown element \"widget\""}`,
+			lines[1],
+		)
+	})
+	t.Run("SARIF", func(t *testing.T) {
+		t.Parallel()
+		h := &fyneloader.SARIFHandler{}
+		ctx := errctx.New(h)
+		_, err := fyneloader.New().Unmarshal(ctx, map[string]interface{}{"root": "widget"})
+		require.IsType(t, fyneloader.LoadError{}, err)
+		h.Diagnostics[0].File = "defs/main.yaml"
+		h.Diagnostics[0].Line = 2
+		h.Diagnostics[0].Column = 7
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, h.WriteLog(buf))
+		var log map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+		require.Equal(t, fyneloader.SARIFVersion, log["version"])
+
+		run := log["runs"].([]interface{})[0].(map[string]interface{})
+		driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"id": "FL125", "name": "unknown-element"},
+		}, driver["rules"])
+		require.Equal(t, []interface{}{
+			map[string]interface{}{
+				"ruleId":  "FL125",
+				"level":   "error",
+				"message": map[string]interface{}{"text": `unknown element "widget"`},
+				"locations": []interface{}{
+					map[string]interface{}{
+						"physicalLocation": map[string]interface{}{
+							"artifactLocation": map[string]interface{}{"uri": "defs/main.yaml"},
+							"region":           map[string]interface{}{"startLine": 2.0, "startColumn": 7.0},
+						},
+						"logicalLocations": []interface{}{
+							map[string]interface{}{"fullyQualifiedName": "root"},
+						},
+					},
+				},
+			},
+		}, run["results"])
+	})
+}
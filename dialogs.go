@@ -0,0 +1,185 @@
+package fyneloader
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// dialogTypes lists the kinds of dialog which may be defined.
+var dialogTypes = []string{ValueConfirm, ValueCustom, ValueForm, ValueInformation}
+
+// Dialog describes a dialog defined in the dialogs section of a definition
+// file.
+type Dialog struct {
+	// Type is one of information, confirm, custom or form.
+	Type        string
+	Title       string
+	Message     string
+	Content     fyne.CanvasObject
+	Items       []*widget.FormItem
+	ConfirmText string
+	DismissText string
+
+	// OnConfirm is called when the confirm button of the dialog is pressed.
+	OnConfirm func()
+
+	// OnDismiss is called when the dialog is closed without being confirmed.
+	OnDismiss func()
+}
+
+// Create creates the dialog over the given window.
+//
+// The dialog is not shown.
+func (d *Dialog) Create(parent fyne.Window) dialog.Dialog {
+	callback := func(confirmed bool) {
+		if confirmed && d.OnConfirm != nil {
+			d.OnConfirm()
+		} else if !confirmed && d.OnDismiss != nil {
+			d.OnDismiss()
+		}
+	}
+
+	switch d.Type {
+	case ValueConfirm:
+		c := dialog.NewConfirm(d.Title, d.Message, callback, parent)
+		if d.ConfirmText != "" {
+			c.SetConfirmText(d.ConfirmText)
+		}
+		if d.DismissText != "" {
+			c.SetDismissText(d.DismissText)
+		}
+		return c
+	case ValueCustom:
+		if d.ConfirmText != "" {
+			return dialog.NewCustomConfirm(
+				d.Title, d.ConfirmText, d.dismissText("Cancel"), d.Content, callback, parent,
+			)
+		}
+		c := dialog.NewCustom(d.Title, d.dismissText("OK"), d.Content, parent)
+		if d.OnDismiss != nil {
+			c.SetOnClosed(d.OnDismiss)
+		}
+		return c
+	case ValueForm:
+		confirm := d.ConfirmText
+		if confirm == "" {
+			confirm = "OK"
+		}
+		return dialog.NewForm(d.Title, confirm, d.dismissText("Cancel"), d.Items, callback, parent)
+	}
+
+	info := dialog.NewInformation(d.Title, d.Message, parent)
+	if d.DismissText != "" {
+		info.SetDismissText(d.DismissText)
+	}
+	if d.OnDismiss != nil {
+		info.SetOnClosed(d.OnDismiss)
+	}
+	return info
+}
+
+func (d *Dialog) dismissText(def string) string {
+	if d.DismissText == "" {
+		return def
+	}
+	return d.DismissText
+}
+
+// ShowDialog creates the dialog with the given name over the parent window and
+// shows it.
+func (d *Document) ShowDialog(name string, parent fyne.Window) (dialog.Dialog, error) {
+	def, ok := d.Dialogs[name]
+	if !ok {
+		return nil, UndefinedDialogError{Name: name}
+	}
+	dlg := def.Create(parent)
+	dlg.Show()
+	return dlg, nil
+}
+
+// readDialogs reads the dialogs section of a definition file.
+func (l *Loader) readDialogs(ctx *errctx.Context, raw interface{}, roots map[string]fyne.CanvasObject) map[string]*Dialog {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	dialogs := make(map[string]*Dialog, len(data))
+	for _, name := range sortedKeys(data) {
+		ctx.Path.Add(mpath.Key(name))
+		if d := l.readDialog(ctx, data[name], roots); d != nil {
+			dialogs[name] = d
+		}
+		ctx.Path.Pop()
+	}
+	return dialogs
+}
+
+func (l *Loader) readDialog(ctx *errctx.Context, raw interface{}, roots map[string]fyne.CanvasObject) *Dialog {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	d := &Dialog{
+		Type:        unpack.RequireStringEnum(ctx, data, KeyType, dialogTypes),
+		Title:       unpack.OptionalString(ctx, data, KeyTitle, ""),
+		ConfirmText: unpack.OptionalString(ctx, data, KeyConfirm, ""),
+		DismissText: unpack.OptionalString(ctx, data, KeyDismiss, ""),
+		OnConfirm:   l.getFnVoidToVoid(ctx, data, KeyOnConfirm),
+		OnDismiss:   l.getFnVoidToVoid(ctx, data, KeyOnDismiss),
+	}
+	switch d.Type {
+	case ValueInformation, ValueConfirm:
+		d.Message = unpack.RequireString(ctx, data, KeyMessage)
+	case ValueCustom:
+		if data[KeyContent] == nil {
+			ctx.ErrorWithKey(maputil.MissingRequiredValueError{Key: KeyContent}, KeyContent)
+			return nil
+		}
+		if d.Content = l.getContent(ctx, data, roots); d.Content == nil {
+			return nil
+		}
+	case ValueForm:
+		d.Items = l.getFormItems(ctx, data, roots)
+	default:
+		return nil
+	}
+	return d
+}
+
+// getFormItems fetches the value of the items key and interprets it as a list
+// of form items, each with a label, content element and optional hint.
+func (l *Loader) getFormItems(
+	ctx *errctx.Context, data map[string]interface{}, roots map[string]fyne.CanvasObject,
+) []*widget.FormItem {
+	raw := unpack.RequireArray(ctx, data, KeyItems)
+	ctx.Path.Add(mpath.Key(KeyItems))
+	defer ctx.Path.Pop()
+
+	items := make([]*widget.FormItem, 0, len(raw))
+	for i, v := range raw {
+		item, err := maputil.AsObject(v)
+		if err != nil {
+			ctx.ErrorWithIndex(err, i)
+			continue
+		}
+		ctx.Path.Add(mpath.Index(i))
+		label := unpack.RequireString(ctx, item, KeyLabel)
+		hint := unpack.OptionalString(ctx, item, KeyHint, "")
+		if item[KeyContent] == nil {
+			ctx.ErrorWithKey(maputil.MissingRequiredValueError{Key: KeyContent}, KeyContent)
+		} else if content := l.getContent(ctx, item, roots); content != nil {
+			items = append(items, &widget.FormItem{Text: label, Widget: content, HintText: hint})
+		}
+		ctx.Path.Pop()
+	}
+	return items
+}
@@ -0,0 +1,107 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestDialogs(t *testing.T) {
+	t.Parallel()
+	t.Run("Load", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+dialogs:
+  saved:
+    type: information
+    title: Saved
+    message: The file was saved.
+  quit:
+    type: confirm
+    title: Quit
+    message: Really quit?
+    confirm: Quit
+    dismiss: Stay
+    on-confirm: quit
+    on-dismiss: [{show: status}]
+  about:
+    type: custom
+    title: About
+    content: about
+  login:
+    type: form
+    title: Log in
+    confirm: Log in
+    items:
+    - {label: Name, content: {type: label, text: name}, hint: Your user name}
+    - {label: Remember, content: {type: check}}
+    on-confirm: login
+status: {type: label, id: status, hidden: true}
+about: label
+`
+		quit, login := false, false
+		l := fyneloader.New()
+		require.NoError(t, l.RegisterFunc("quit", func() { quit = true }))
+		require.NoError(t, l.RegisterFunc("login", func() { login = true }))
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		doc, err := l.LoadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount(), sb.String())
+		require.Len(t, doc.Dialogs, 4)
+
+		require.Equal(t, "The file was saved.", doc.Dialogs["saved"].Message)
+		confirm := doc.Dialogs["quit"]
+		require.Equal(t, "Quit", confirm.ConfirmText)
+		require.Equal(t, "Stay", confirm.DismissText)
+		confirm.OnConfirm()
+		require.True(t, quit)
+		confirm.OnDismiss()
+		require.True(t, doc.Roots["status"].Visible())
+		require.Same(t, doc.Roots["about"], doc.Dialogs["about"].Content)
+
+		form := doc.Dialogs["login"]
+		require.Len(t, form.Items, 2)
+		require.Equal(t, "Name", form.Items[0].Text)
+		require.Equal(t, "Your user name", form.Items[0].HintText)
+		require.IsType(t, &widget.Check{}, form.Items[1].Widget)
+		form.OnConfirm()
+		require.True(t, login)
+
+		win := test.NewWindow(widget.NewLabel(""))
+		defer win.Close()
+		win.Resize(fyne.NewSize(400, 400))
+		for name := range doc.Dialogs {
+			dlg, err := doc.ShowDialog(name, win)
+			require.NoError(t, err, name)
+			dlg.Hide()
+		}
+		_, err = doc.ShowDialog("missing", win)
+		require.Equal(t, fyneloader.UndefinedDialogError{Name: "missing"}, err)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+dialogs:
+  a: {type: alert}
+  b: {type: confirm}
+  c: {type: custom}
+  d: {type: form, items: [{label: Name}]}
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, 4, ctx.ErrorCount(), sb.String())
+		require.Contains(t, sb.String(), "dialogs.a.type: ")
+		require.Contains(t, sb.String(), "dialogs.b.message: ")
+		require.Contains(t, sb.String(), "dialogs.c.content: ")
+		require.Contains(t, sb.String(), "dialogs.d.items[0].content: ")
+	})
+}
@@ -0,0 +1,34 @@
+package fyneloader
+
+import "fyne.io/fyne/v2"
+
+// Document holds everything loaded from a single definition file.
+type Document struct {
+	// Roots holds the root elements of the definition by name.
+	Roots map[string]fyne.CanvasObject
+
+	// Animations holds the animations defined in the animations section by
+	// name.
+	Animations map[string]*Animation
+
+	// Dialogs holds the dialogs defined in the dialogs section by name.
+	Dialogs map[string]*Dialog
+
+	// Menus holds the menus defined in the menus section by name.
+	Menus map[string]*fyne.Menu
+
+	// MainMenus holds the main menus defined in the main-menus section by
+	// name.
+	MainMenus map[string]*fyne.MainMenu
+
+	// Shortcuts holds the shortcuts declared at the root of the definition,
+	// which apply to every window.
+	Shortcuts []Shortcut
+
+	// Theme is the theme defined in the theme section, or nil if the
+	// definition has no theme section.
+	Theme fyne.Theme
+
+	// Windows holds the windows defined in the windows section by name.
+	Windows map[string]*Window
+}
@@ -2,7 +2,10 @@ package fyneloader
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
+
+	"fyne.io/fyne/v2"
 )
 
 // ConstError is a simple constant error type.
@@ -17,6 +20,10 @@ const (
 	// loader with that disabled.
 	ErrFetchURIDisabled ConstError = "loading images from URIs is disabled"
 
+	// ErrNoPreferences indicates that an element was bound to a preference
+	// with no preferences available.
+	ErrNoPreferences ConstError = "no preferences available"
+
 	// ErrNoWidgetType indicates that an element map did not have a type field.
 	ErrNoWidgetType ConstError = "no type tag on element definition"
 
@@ -26,8 +33,52 @@ const (
 
 	// ErrInvalidOption indicates that an option given was not valid.
 	ErrInvalidOption ConstError = "invalid option"
+
+	// ErrOpacityRange indicates that an opacity was not between 0 and 1.
+	ErrOpacityRange ConstError = "opacity must be between 0 and 1"
+
+	// ErrRepeatOutsideChildren indicates that a repeat element was used
+	// somewhere other than a children array.
+	ErrRepeatOutsideChildren ConstError = "repeat used outside of a children array"
+
+	// ErrSlotOutsideComponent indicates that a slot placeholder was used
+	// outside of the content of a component.
+	ErrSlotOutsideComponent ConstError = "slot used outside of a component"
+
+	// ErrUnterminatedReference indicates that a string contained a `${` with
+	// no matching `}`.
+	ErrUnterminatedReference ConstError = "unterminated reference"
+
+	// ErrNoMethods indicates that a value given to RegisterMethods had no
+	// exported methods.
+	ErrNoMethods ConstError = "no exported methods"
+
+	// ErrNilReceiver indicates that a value given to RegisterMethods was a nil
+	// pointer, whose methods would panic when called.
+	ErrNilReceiver ConstError = "methods of a nil pointer may not be registered"
 )
 
+// ActionTargetError is an error which indicates that the target of an action
+// does not support that action.
+type ActionTargetError struct {
+	Action string
+	Target fyne.CanvasObject
+}
+
+func (e ActionTargetError) Error() string {
+	return fmt.Sprintf("action %q is not supported by %T", e.Action, e.Target)
+}
+
+// AnimationTargetError is an error which indicates that the target of a color
+// or opacity animation has no color to animate.
+type AnimationTargetError struct {
+	Target fyne.CanvasObject
+}
+
+func (e AnimationTargetError) Error() string {
+	return fmt.Sprintf("colors of %T may not be animated", e.Target)
+}
+
 // ArrayIndexOutOfBoundsError is an error indicating that the given index was
 // out of bounds for the source array.
 type ArrayIndexOutOfBoundsError struct {
@@ -38,6 +89,38 @@ func (e ArrayIndexOutOfBoundsError) Error() string {
 	return fmt.Sprintf("array index %d out of bounds", e.Index)
 }
 
+// BindingTypeError is an error which indicates that a binding is not of a
+// type the element may be bound to.
+type BindingTypeError struct {
+	Name    string
+	Binding interface{}
+}
+
+func (e BindingTypeError) Error() string {
+	return fmt.Sprintf("binding %q of type %T may not be used here", e.Name, e.Binding)
+}
+
+// ComponentNameError is an error which indicates that a component was given a
+// name which is already used by an element type.
+type ComponentNameError struct {
+	Name string
+}
+
+func (e ComponentNameError) Error() string {
+	return fmt.Sprintf("component %q conflicts with an element type", e.Name)
+}
+
+// ComponentParamError is an error which indicates that a parameter was not
+// valid for the given component.
+type ComponentParamError struct {
+	Component string
+	Name      string
+}
+
+func (e ComponentParamError) Error() string {
+	return fmt.Sprintf("invalid parameter %q for component %q", e.Name, e.Component)
+}
+
 // ConflictingKeysError is an error which indicates that some keys in the
 // configuration conflict.
 type ConflictingKeysError struct {
@@ -56,6 +139,89 @@ func (e ConflictingKeysError) Error() string {
 	return builder.String()
 }
 
+// ConversionError is an error which indicates that an argument could not be
+// converted to the type of a parameter of an adapted function without
+// changing its value.
+type ConversionError struct {
+	Value interface{}
+	Type  reflect.Type
+}
+
+func (e ConversionError) Error() string {
+	return fmt.Sprintf("cannot convert %v to %s", e.Value, e.Type)
+}
+
+// DuplicateFunctionError is an error which indicates that functions with the
+// given names were already registered.
+type DuplicateFunctionError struct {
+	Names []string
+}
+
+func (e DuplicateFunctionError) Error() string {
+	quoted := make([]string, len(e.Names))
+	for i, name := range e.Names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	if len(quoted) == 1 {
+		return fmt.Sprintf("function %s is already registered", quoted[0])
+	}
+	return fmt.Sprintf("functions %s are already registered", strings.Join(quoted, ", "))
+}
+
+// DuplicateContentError is an error which indicates that a root element was
+// used as the content of more than one window or dialog; an element may only
+// be shown in one place at a time.
+type DuplicateContentError struct {
+	Root string
+}
+
+func (e DuplicateContentError) Error() string {
+	return fmt.Sprintf("root %q is already used as content", e.Root)
+}
+
+// DuplicateIDError is an error which indicates that more than one element was
+// given the same ID.
+type DuplicateIDError struct {
+	ID string
+}
+
+func (e DuplicateIDError) Error() string {
+	return fmt.Sprintf("duplicate element id %q", e.ID)
+}
+
+// DuplicateMasterError is an error which indicates that more than one window
+// was marked as the master window.
+type DuplicateMasterError struct {
+	Window string
+	Master string
+}
+
+func (e DuplicateMasterError) Error() string {
+	return fmt.Sprintf("window %q may not be the master window, as %q already is", e.Window, e.Master)
+}
+
+// DuplicateShortcutError is an error which indicates that a key combination
+// was given more than one shortcut in the same list.
+type DuplicateShortcutError struct {
+	Shortcut string
+}
+
+func (e DuplicateShortcutError) Error() string {
+	return fmt.Sprintf("duplicate shortcut %q", e.Shortcut)
+}
+
+// ExpressionError is an error which indicates that a condition expression
+// could not be parsed.
+type ExpressionError struct {
+	Expr string
+	Pos  int
+	Msg  string
+}
+
+func (e ExpressionError) Error() string {
+	return fmt.Sprintf("syntax error at position %d of %q: %s", e.Pos, e.Expr, e.Msg)
+}
+
 // FunctionTypeError is an error which indicates that a function type did not
 // match any of the allowed types.
 type FunctionTypeError struct {
@@ -66,6 +232,198 @@ func (e FunctionTypeError) Error() string {
 	return fmt.Sprintf("invalid function type %T", e.Func)
 }
 
+// FunctionSignatureError is an error which indicates that a registered
+// function could not be adapted to the signature it was used with.
+type FunctionSignatureError struct {
+	Name     string
+	Expected reflect.Type
+	Actual   reflect.Type
+}
+
+func (e FunctionSignatureError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("function has signature %s; expected %s", e.Actual, e.Expected)
+	}
+	return fmt.Sprintf("function %q has signature %s; expected %s", e.Name, e.Actual, e.Expected)
+}
+
+// InvalidColorError is an error which indicates that a color value could not
+// be parsed.
+type InvalidColorError struct {
+	Value string
+}
+
+func (e InvalidColorError) Error() string {
+	return fmt.Sprintf("invalid color %q; expected #rgb, #rgba, #rrggbb or #rrggbbaa", e.Value)
+}
+
+// LoadError is returned by Load when diagnostics at least as severe as the
+// threshold of the loader were reported; they have already been passed to the
+// handler of the context.
+type LoadError struct {
+	Errors []error
+}
+
+func (e LoadError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	rest := e.Errors[1:]
+	noun := "diagnostic"
+	switch severity := SeverityOf(rest[0]); severity {
+	case SeverityError, SeverityWarning:
+		noun = severity.String()
+		for _, err := range rest[1:] {
+			if SeverityOf(err) != severity {
+				noun = "diagnostic"
+				break
+			}
+		}
+	}
+	if len(rest) > 1 {
+		noun += "s"
+	}
+	return fmt.Sprintf("%s (and %d more %s)", e.Errors[0].Error(), len(rest), noun)
+}
+
+// MissingTranslationError is an error which indicates that no catalog contains
+// a translation with the given ID.
+type MissingTranslationError struct {
+	ID     string
+	Locale string
+}
+
+func (e MissingTranslationError) Error() string {
+	return fmt.Sprintf("no translation %q for locale %q", e.ID, e.Locale)
+}
+
+// PanicError is an error which indicates that the constructor of an element
+// panicked; Stack holds the stack trace at the point of the panic.
+type PanicError struct {
+	Path     string
+	TypeName string
+	Value    interface{}
+	Stack    []byte
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("element %q panicked: %v", e.TypeName, e.Value)
+}
+
+// Unwrap returns the value of the panic if it was an error.
+func (e PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// PositionError wraps an error with the position in the definition file at
+// which it occurred.
+type PositionError struct {
+	Position Position
+	Err      error
+}
+
+func (e PositionError) Error() string {
+	return e.Position.String() + ": " + e.Err.Error()
+}
+
+func (e PositionError) Unwrap() error {
+	return e.Err
+}
+
+// PreferenceTypeError is an error which indicates that the preference key was
+// given on an element which has no value to persist.
+type PreferenceTypeError struct {
+	Target fyne.CanvasObject
+}
+
+func (e PreferenceTypeError) Error() string {
+	return fmt.Sprintf("preferences are not supported by %T", e.Target)
+}
+
+// RecursiveComponentError is an error which indicates that a component was
+// used within its own content.
+type RecursiveComponentError struct {
+	Name string
+}
+
+func (e RecursiveComponentError) Error() string {
+	return fmt.Sprintf("component %q is used recursively", e.Name)
+}
+
+// ShortcutError is an error which indicates that a keyboard shortcut could not
+// be parsed.
+type ShortcutError struct {
+	Shortcut string
+	Msg      string
+}
+
+func (e ShortcutError) Error() string {
+	return fmt.Sprintf("invalid shortcut %q: %s", e.Shortcut, e.Msg)
+}
+
+// SlotChildrenError is an error which indicates that several children were
+// given to a component whose slot holds a single element.
+type SlotChildrenError struct {
+	Component string
+	Count     int
+}
+
+func (e SlotChildrenError) Error() string {
+	return fmt.Sprintf("component %q has a slot for one child but was given %d", e.Component, e.Count)
+}
+
+// StyleKeyError is an error which indicates that a style or type default set
+// a key which may only be given on an element itself.
+type StyleKeyError struct {
+	Style string
+	Key   string
+}
+
+func (e StyleKeyError) Error() string {
+	return fmt.Sprintf("style %q may not set %q", e.Style, e.Key)
+}
+
+// UndefinedAnimationError is an error which indicates that no animation with
+// the given name is defined.
+type UndefinedAnimationError struct {
+	Name string
+}
+
+func (e UndefinedAnimationError) Error() string {
+	return fmt.Sprintf("no animation %q defined", e.Name)
+}
+
+// UndefinedBindingError is an error which indicates that no binding with the
+// given name was registered.
+type UndefinedBindingError struct {
+	Name string
+}
+
+func (e UndefinedBindingError) Error() string {
+	return fmt.Sprintf("no binding %q registered", e.Name)
+}
+
+// UndefinedDataError is an error which indicates that the data set with the
+// given name was not registered.
+type UndefinedDataError struct {
+	Name string
+}
+
+func (e UndefinedDataError) Error() string {
+	return fmt.Sprintf("no data set %q defined", e.Name)
+}
+
+// UndefinedDialogError is an error which indicates that no dialog with the
+// given name is defined.
+type UndefinedDialogError struct {
+	Name string
+}
+
+func (e UndefinedDialogError) Error() string {
+	return fmt.Sprintf("no dialog %q defined", e.Name)
+}
+
 // UndefinedFunctionError is an error which indicates that the function with
 // the given name was not registered.
 type UndefinedFunctionError struct {
@@ -76,6 +434,56 @@ func (e UndefinedFunctionError) Error() string {
 	return fmt.Sprintf("no function %q defined", e.Name)
 }
 
+// UndefinedMenuError is an error which indicates that a main menu referenced a
+// menu which is not defined in the menus section.
+type UndefinedMenuError struct {
+	Name string
+}
+
+func (e UndefinedMenuError) Error() string {
+	return fmt.Sprintf("no menu %q defined", e.Name)
+}
+
+// UndefinedReferenceError is an error which indicates that a `${name}`
+// reference could not be resolved.
+type UndefinedReferenceError struct {
+	Name string
+}
+
+func (e UndefinedReferenceError) Error() string {
+	return fmt.Sprintf("undefined reference %q", e.Name)
+}
+
+// UndefinedStyleError is an error which indicates that a class named a style
+// which is not defined in the styles section.
+type UndefinedStyleError struct {
+	Name string
+}
+
+func (e UndefinedStyleError) Error() string {
+	return fmt.Sprintf("no style %q defined", e.Name)
+}
+
+// UnknownIdentifierError is an error which indicates that a condition
+// expression referenced an undefined variable.
+type UnknownIdentifierError struct {
+	Name string
+}
+
+func (e UnknownIdentifierError) Error() string {
+	return fmt.Sprintf("unknown identifier %q", e.Name)
+}
+
+// UnknownActionError is an error which indicates that the action with the
+// given kind was not registered.
+type UnknownActionError struct {
+	Kind string
+}
+
+func (e UnknownActionError) Error() string {
+	return fmt.Sprintf("unknown action %q", e.Kind)
+}
+
 // UnknownElementType is an error which indicates that the element with the
 // given name is unknown.
 type UnknownElementType struct {
@@ -85,3 +493,54 @@ type UnknownElementType struct {
 func (e UnknownElementType) Error() string {
 	return fmt.Sprintf("unknown element %q", e.TypeName)
 }
+
+// UnknownKeyError is an error which indicates that an element has a key which
+// is not accepted by its type.
+type UnknownKeyError struct {
+	Key        string
+	Suggestion string
+}
+
+func (e UnknownKeyError) Error() string {
+	if e.Suggestion == "" {
+		return fmt.Sprintf("unknown key %q", e.Key)
+	}
+	return fmt.Sprintf("unknown key %q, did you mean %q?", e.Key, e.Suggestion)
+}
+
+// UnknownTargetError is an error which indicates that no element or root with
+// the given ID exists.
+type UnknownTargetError struct {
+	ID string
+}
+
+func (e UnknownTargetError) Error() string {
+	return fmt.Sprintf("unknown target %q", e.ID)
+}
+
+// Info wraps a diagnostic which is only informational.
+type Info struct {
+	Err error
+}
+
+func (e Info) Error() string {
+	return "info: " + e.Err.Error()
+}
+
+func (e Info) Unwrap() error {
+	return e.Err
+}
+
+// Warning wraps an error which does not prevent the definition from being
+// loaded, such as a missing translation.
+type Warning struct {
+	Err error
+}
+
+func (e Warning) Error() string {
+	return "warning: " + e.Err.Error()
+}
+
+func (e Warning) Unwrap() error {
+	return e.Err
+}
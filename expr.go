@@ -0,0 +1,418 @@
+package fyneloader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+)
+
+// exprNode is a node of a parsed condition expression.
+type exprNode interface {
+	eval(lookup lookupFn) (interface{}, error)
+	idents(out []string) []string
+}
+
+type exprLiteral struct {
+	value interface{}
+}
+
+type exprIdent struct {
+	name string
+}
+
+type exprNot struct {
+	operand exprNode
+}
+
+type exprBinary struct {
+	op    string
+	left  exprNode
+	right exprNode
+}
+
+func (n exprLiteral) eval(lookup lookupFn) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n exprLiteral) idents(out []string) []string {
+	return out
+}
+
+func (n exprIdent) eval(lookup lookupFn) (interface{}, error) {
+	v, ok := lookup(n.name)
+	if !ok {
+		return nil, UnknownIdentifierError{Name: n.name}
+	}
+	return v, nil
+}
+
+func (n exprIdent) idents(out []string) []string {
+	return append(out, n.name)
+}
+
+func (n exprNot) eval(lookup lookupFn) (interface{}, error) {
+	v, err := n.operand.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+	return !truthy(v), nil
+}
+
+func (n exprNot) idents(out []string) []string {
+	return n.operand.idents(out)
+}
+
+func (n exprBinary) eval(lookup lookupFn) (interface{}, error) {
+	left, err := n.left.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "&&":
+		if !truthy(left) {
+			return false, nil
+		}
+		right, err := n.right.eval(lookup)
+		return truthy(right), err
+	case "||":
+		if truthy(left) {
+			return true, nil
+		}
+		right, err := n.right.eval(lookup)
+		return truthy(right), err
+	}
+
+	right, err := n.right.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	}
+
+	cmp, err := compare(left, right)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+func (n exprBinary) idents(out []string) []string {
+	return n.right.idents(n.left.idents(out))
+}
+
+// truthy converts a value to a boolean; null, false, zero, and empty values
+// are false while everything else is true.
+func truthy(v interface{}) bool {
+	switch d := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return d
+	case string:
+		return d != ""
+	case []interface{}:
+		return len(d) > 0
+	case map[string]interface{}:
+		return len(d) > 0
+	}
+	if n, err := maputil.AsNumber(v); err == nil {
+		return n != 0
+	}
+	return true
+}
+
+func equal(left, right interface{}) bool {
+	ln, lerr := maputil.AsNumber(left)
+	rn, rerr := maputil.AsNumber(right)
+	if lerr == nil && rerr == nil {
+		return ln == rn
+	}
+	switch l := left.(type) {
+	case nil, bool, string:
+		return left == right
+	default:
+		return fmt.Sprint(l) == fmt.Sprint(right)
+	}
+}
+
+func compare(left, right interface{}) (int, error) {
+	ln, lerr := maputil.AsNumber(left)
+	rn, rerr := maputil.AsNumber(right)
+	if lerr == nil && rerr == nil {
+		switch {
+		case ln < rn:
+			return -1, nil
+		case ln > rn:
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if lok && rok {
+		return strings.Compare(ls, rs), nil
+	}
+	return 0, maputil.InvalidTypeError{
+		Actual:   maputil.TypeName(left) + " and " + maputil.TypeName(right),
+		Expected: []string{"two numbers", "two strings"},
+	}
+}
+
+// exprParser is a recursive descent parser for condition expressions.
+type exprParser struct {
+	src string
+	pos int
+}
+
+// parseExpr parses a condition expression.
+//
+// Expressions consist of identifiers, string, number, boolean and null
+// literals, the comparison operators `==`, `!=`, `<`, `<=`, `>` and `>=`, the
+// logical operators `!`, `&&` and `||`, and parentheses.
+func parseExpr(src string) (exprNode, error) {
+	p := &exprParser{src: src}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:p.pos+1])
+	}
+	return n, nil
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return ExpressionError{
+		Expr: p.src,
+		Pos:  p.pos,
+		Msg:  fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *exprParser) accept(op string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], op) {
+		p.pos += len(op)
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = exprBinary{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = exprBinary{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], "!") && !strings.HasPrefix(p.src[p.pos:], "!=") {
+		p.pos++
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return exprNot{operand: operand}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *exprParser) parseCompare() (exprNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	// Two character operators must be checked before their one character
+	// prefixes.
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.accept(op) {
+			right, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			return exprBinary{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unexpected end of expression")
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.errorf("expected %q", ")")
+		}
+		return n, nil
+	case c == '"' || c == '\'':
+		return p.parseString(c)
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case isIdentStart(c):
+		start := p.pos
+		for p.pos < len(p.src) && (isIdentStart(p.src[p.pos]) || p.src[p.pos] == '.' ||
+			(p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+		name := p.src[start:p.pos]
+		switch name {
+		case "true":
+			return exprLiteral{value: true}, nil
+		case "false":
+			return exprLiteral{value: false}, nil
+		case "null":
+			return exprLiteral{value: nil}, nil
+		}
+		return exprIdent{name: name}, nil
+	}
+	return nil, p.errorf("unexpected %q", p.src[p.pos:p.pos+1])
+}
+
+func (p *exprParser) parseString(quote byte) (exprNode, error) {
+	start := p.pos
+	p.pos++
+	builder := &strings.Builder{}
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case quote:
+			p.pos++
+			return exprLiteral{value: builder.String()}, nil
+		case '\\':
+			if p.pos+1 < len(p.src) {
+				p.pos++
+				c = p.src[p.pos]
+			}
+		}
+		builder.WriteByte(c)
+		p.pos++
+	}
+	p.pos = start
+	return nil, p.errorf("unterminated string")
+}
+
+func (p *exprParser) parseNumber() (exprNode, error) {
+	start := p.pos
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.src) && (p.src[p.pos] == '.' || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+		p.pos++
+	}
+	text := p.src[start:p.pos]
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number %q", text)
+	}
+	return exprLiteral{value: v}, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// included evaluates the 'if' key of an element, returning true if the
+// element should be created.
+//
+// Errors are sent to the context, and an element with an invalid condition
+// is not created.
+func (l *Loader) included(ctx *errctx.Context, data map[string]interface{}) bool {
+	raw, ok := data[KeyIf]
+	if !ok {
+		return true
+	}
+
+	switch cond := raw.(type) {
+	case bool:
+		return cond
+	case string:
+		n, err := parseExpr(cond)
+		if err != nil {
+			ctx.ErrorWithKey(err, KeyIf)
+			return false
+		}
+
+		valid := true
+		for _, name := range n.idents(nil) {
+			if _, ok := l.lookup(name); !ok {
+				ctx.ErrorWithKey(UnknownIdentifierError{Name: name}, KeyIf)
+				valid = false
+			}
+		}
+		if !valid {
+			return false
+		}
+
+		v, err := n.eval(l.lookup)
+		if err != nil {
+			ctx.ErrorWithKey(err, KeyIf)
+			return false
+		}
+		return truthy(v)
+	}
+	ctx.ErrorWithKey(maputil.InvalidTypeError{
+		Actual:   maputil.TypeName(raw),
+		Expected: []string{maputil.TypeBoolean, maputil.TypeString},
+	}, KeyIf)
+	return false
+}
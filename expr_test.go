@@ -0,0 +1,72 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestConditions(t *testing.T) {
+	t.Parallel()
+
+	load := func(t *testing.T, cond string) (int, *errctx.Context) {
+		t.Helper()
+		doc := "root:\n  type: vbox\n  children:\n  - {type: label, if: '" + cond + "'}\n  - label\n"
+		l := fyneloader.New()
+		l.SetVar("platform", "desktop")
+		l.SetVar("count", 3)
+		l.SetVar("features", map[string]interface{}{"beta": true, "alpha": false})
+		ctx := errctx.New()
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		if ctx.ErrorCount() == 0 {
+			require.NoError(t, err)
+		} else {
+			require.IsType(t, fyneloader.LoadError{}, err)
+		}
+		return len(roots["root"].(*fyne.Container).Objects), ctx
+	}
+
+	t.Run("Evaluate", func(t *testing.T) {
+		t.Parallel()
+		for cond, expected := range map[string]bool{
+			`platform == "desktop" && features.beta`:   true,
+			`platform == "mobile" || features.alpha`:   false,
+			`!features.alpha`:                          true,
+			`!(count > 2 && count <= 3)`:               false,
+			`count != 3.0`:                             false,
+			`count >= -1 && "a" < "b"`:                 true,
+			`features.alpha || (platform != "mobile")`: true,
+			`true && null`:                             false,
+		} {
+			count, ctx := load(t, cond)
+			require.Zero(t, ctx.ErrorCount(), cond)
+			if expected {
+				require.Equal(t, 2, count, cond)
+			} else {
+				require.Equal(t, 1, count, cond)
+			}
+		}
+	})
+	t.Run("UnknownIdentifier", func(t *testing.T) {
+		t.Parallel()
+		count, ctx := load(t, `platform == "mobile" && features.gamma`)
+		require.Equal(t, 1, count)
+		require.Equal(t, 1, ctx.ErrorCount())
+		require.Equal(t, fyneloader.UnknownIdentifierError{Name: "features.gamma"}, ctx.LastError())
+	})
+	t.Run("SyntaxError", func(t *testing.T) {
+		t.Parallel()
+		count, ctx := load(t, `platform = "desktop"`)
+		require.Equal(t, 1, count)
+		require.Equal(t, 1, ctx.ErrorCount())
+		require.Equal(t, fyneloader.ExpressionError{
+			Expr: `platform = "desktop"`,
+			Pos:  9,
+			Msg:  `unexpected "="`,
+		}, ctx.LastError())
+	})
+}
@@ -0,0 +1,225 @@
+package fyneloader
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// FilePicker is a widget which shows the path of a chosen file or folder,
+// along with a button which opens a dialog to choose it.
+type FilePicker struct {
+	widget.BaseWidget
+
+	// Mode is one of open, save or folder, selecting the dialog to show.
+	Mode string
+
+	// Filter limits the files shown by the dialog to those with the given
+	// extensions. It is not used for folders.
+	Filter []string
+
+	// Location is the folder the dialog starts in, if not nil.
+	Location fyne.ListableURI
+
+	// FileName is the initial file name of the save dialog.
+	FileName string
+
+	// URI is the chosen file or folder, or nil if none has been chosen.
+	URI fyne.URI
+
+	// OnChanged is called with the chosen file or folder.
+	OnChanged func(fyne.URI)
+
+	// Window is the window to show the dialog over. If nil, the window
+	// containing the picker is used.
+	Window fyne.Window
+
+	label    *widget.Label
+	button   *widget.Button
+	bound    binding.URI
+	listener binding.DataListener
+}
+
+// NewFilePicker creates a new file picker using the given dialog mode.
+func NewFilePicker(mode string, onChanged func(fyne.URI)) *FilePicker {
+	p := &FilePicker{Mode: mode, OnChanged: onChanged}
+	p.label = widget.NewLabel("")
+	p.label.Wrapping = fyne.TextTruncate
+	p.button = widget.NewButtonWithIcon("", theme.FolderOpenIcon(), p.Open)
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+// SetPlaceHolder sets the text shown before a file is chosen.
+func (p *FilePicker) SetPlaceHolder(text string) {
+	p.label.Text = text
+	p.label.Refresh()
+}
+
+// SetText sets the text of the button which opens the dialog.
+func (p *FilePicker) SetText(text string) {
+	p.button.SetText(text)
+}
+
+// SetURI sets the chosen file or folder, updates the bound data if any and
+// calls OnChanged.
+func (p *FilePicker) SetURI(uri fyne.URI) {
+	p.showURI(uri)
+	if p.bound != nil {
+		_ = p.bound.Set(uri)
+	}
+	if p.OnChanged != nil {
+		p.OnChanged(uri)
+	}
+}
+
+// showURI sets the chosen file or folder without notifying anything.
+func (p *FilePicker) showURI(uri fyne.URI) {
+	p.URI = uri
+	if uri.Scheme() == "file" {
+		p.label.SetText(uri.Path())
+	} else {
+		p.label.SetText(uri.String())
+	}
+}
+
+// Bind connects the chosen file or folder to a data binding: choosing a file
+// sets the binding, and setting the binding changes the chosen file.
+func (p *FilePicker) Bind(data binding.URI) {
+	p.Unbind()
+	p.bound = data
+	p.listener = binding.NewDataListener(func() {
+		uri, err := data.Get()
+		if err == nil && uri != nil && (p.URI == nil || uri.String() != p.URI.String()) {
+			p.showURI(uri)
+		}
+	})
+	data.AddListener(p.listener)
+}
+
+// BindPath is like Bind for a binding holding the path of the chosen file, or
+// its URI if it is not a local file.
+func (p *FilePicker) BindPath(data binding.String) {
+	p.Bind(pathBinding{data})
+}
+
+// Unbind disconnects the picker from its data binding, if any.
+func (p *FilePicker) Unbind() {
+	if p.bound != nil {
+		p.bound.RemoveListener(p.listener)
+		p.bound = nil
+		p.listener = nil
+	}
+}
+
+// Disable disables the button which opens the dialog.
+func (p *FilePicker) Disable() {
+	p.button.Disable()
+}
+
+// Enable enables the button which opens the dialog.
+func (p *FilePicker) Enable() {
+	p.button.Enable()
+}
+
+// Disabled returns true if the picker is disabled.
+func (p *FilePicker) Disabled() bool {
+	return p.button.Disabled()
+}
+
+// Open shows the dialog for the mode of the picker.
+func (p *FilePicker) Open() {
+	win := p.window()
+	if win == nil {
+		return
+	}
+
+	var d *dialog.FileDialog
+	switch p.Mode {
+	case ValueFolder:
+		d = dialog.NewFolderOpen(func(uri fyne.ListableURI, err error) {
+			if err == nil && uri != nil {
+				p.SetURI(uri)
+			}
+		}, win)
+	case ValueSave:
+		d = dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+			if err == nil && w != nil {
+				w.Close()
+				p.SetURI(w.URI())
+			}
+		}, win)
+		if p.FileName != "" {
+			d.SetFileName(p.FileName)
+		}
+	default:
+		d = dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err == nil && r != nil {
+				r.Close()
+				p.SetURI(r.URI())
+			}
+		}, win)
+	}
+	if len(p.Filter) > 0 && p.Mode != ValueFolder {
+		d.SetFilter(storage.NewExtensionFileFilter(p.Filter))
+	}
+	if p.Location != nil {
+		d.SetLocation(p.Location)
+	}
+	d.Show()
+}
+
+// window returns the window to show the dialog over.
+func (p *FilePicker) window() fyne.Window {
+	if p.Window != nil {
+		return p.Window
+	}
+	app := fyne.CurrentApp()
+	if app == nil {
+		return nil
+	}
+	windows := app.Driver().AllWindows()
+	canvas := app.Driver().CanvasForObject(p)
+	for _, w := range windows {
+		if w.Canvas() == canvas {
+			return w
+		}
+	}
+	if len(windows) > 0 {
+		return windows[0]
+	}
+	return nil
+}
+
+func (p *FilePicker) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewBorder(nil, nil, nil, p.button, p.label))
+}
+
+// pathBinding adapts a string binding holding a path to a URI binding.
+type pathBinding struct {
+	binding.String
+}
+
+func (b pathBinding) Get() (fyne.URI, error) {
+	s, err := b.String.Get()
+	if err != nil || s == "" {
+		return nil, err
+	}
+	if strings.Contains(s, "://") {
+		return storage.ParseURI(s)
+	}
+	return storage.NewFileURI(s), nil
+}
+
+func (b pathBinding) Set(uri fyne.URI) error {
+	if uri.Scheme() == "file" {
+		return b.String.Set(uri.Path())
+	}
+	return b.String.Set(uri.String())
+}
@@ -0,0 +1,199 @@
+package fyneloader_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestFilePicker(t *testing.T) {
+	t.Parallel()
+	t.Run("Load", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		doc := `
+root:
+  type: vbox
+  children:
+  - type: file-picker
+    mode: save
+    filter: [.yaml, .json]
+    location: ` + dir + `
+    file-name: ui.yaml
+    text: Browse
+    placeholder: No file chosen
+    func: chosen
+  - {type: file-picker, mode: folder, func: [{hide: status}]}
+  - {type: label, id: status}
+`
+		var chosen fyne.URI
+		l := fyneloader.New()
+		require.NoError(t, l.RegisterFunc("chosen", func(uri fyne.URI) { chosen = uri }))
+		ctx := errctx.New()
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		objs := roots["root"].(*fyne.Container).Objects
+		picker := objs[0].(*fyneloader.FilePicker)
+		require.Equal(t, fyneloader.ValueSave, picker.Mode)
+		require.Equal(t, []string{".yaml", ".json"}, picker.Filter)
+		require.Equal(t, "ui.yaml", picker.FileName)
+		require.Equal(t, dir, picker.Location.Path())
+
+		uri := storage.NewFileURI(dir + "/ui.yaml")
+		picker.SetURI(uri)
+		require.Equal(t, uri, chosen)
+		require.Equal(t, uri, picker.URI)
+
+		objs[1].(*fyneloader.FilePicker).SetURI(storage.NewFileURI(dir))
+		require.False(t, objs[2].Visible())
+
+		win := test.NewWindow(roots["root"])
+		defer win.Close()
+		win.Resize(fyne.NewSize(600, 600))
+		picker.Open()
+	})
+	t.Run("Save", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		var chosen fyne.URI
+		picker := fyneloader.NewFilePicker(fyneloader.ValueSave, func(uri fyne.URI) { chosen = uri })
+		picker.Filter = []string{".yaml"}
+		picker.FileName = "ui.yaml"
+		location, err := storage.ListerForURI(storage.NewFileURI(dir))
+		require.NoError(t, err)
+		picker.Location = location
+
+		win := test.NewWindow(picker)
+		defer win.Close()
+		win.Resize(fyne.NewSize(800, 600))
+		picker.Open()
+
+		overlay := win.Canvas().Overlays().Top()
+		require.NotNil(t, overlay)
+		var save *widget.Button
+		for _, obj := range walk(overlay) {
+			if b, ok := obj.(*widget.Button); ok && b.Text == "Save" {
+				save = b
+			}
+		}
+		require.NotNil(t, save)
+		test.Tap(save)
+
+		require.NotNil(t, chosen)
+		require.Equal(t, storage.NewFileURI(filepath.Join(dir, "ui.yaml")).String(), chosen.String())
+		require.Equal(t, chosen, picker.URI)
+	})
+	t.Run("Filter", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root: {type: file-picker, filter: [png, .JPG, ""]}
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, "root.filter[2]: 2:47: invalid option\n", sb.String())
+		require.Equal(t, []string{".png", ".JPG"}, roots["root"].(*fyneloader.FilePicker).Filter)
+	})
+	t.Run("Bind", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		const doc = `
+root:
+  type: vbox
+  children:
+  - {type: file-picker, bind: uri}
+  - {type: file-picker, bind: path}
+  - {type: file-picker, bind: missing}
+  - {type: file-picker, bind: count}
+`
+		uri, path := binding.NewURI(), binding.NewString()
+		l := fyneloader.New()
+		l.RegisterBinding("uri", uri)
+		l.RegisterBinding("path", path)
+		l.RegisterBinding("count", binding.NewInt())
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"root.children[2].bind: 7:25: no binding \"missing\" registered\n"+
+				"root.children[3].bind: 8:25: binding \"count\" of type *binding.boundInt may not be used here\n",
+			sb.String(),
+		)
+
+		objs := roots["root"].(*fyne.Container).Objects
+		first := objs[0].(*fyneloader.FilePicker)
+		file := storage.NewFileURI(filepath.Join(dir, "a.txt"))
+		first.SetURI(file)
+		got, err := uri.Get()
+		require.NoError(t, err)
+		require.Equal(t, file, got)
+
+		second := objs[1].(*fyneloader.FilePicker)
+		require.NoError(t, path.Set(filepath.Join(dir, "b.txt")))
+		waitForBindings()
+		require.Equal(t, filepath.Join(dir, "b.txt"), second.URI.Path())
+		second.SetURI(file)
+		p, err := path.Get()
+		require.NoError(t, err)
+		require.Equal(t, file.Path(), p)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: file-picker
+  mode: pick
+  location: /does/not/exist
+  func: missing
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, 3, ctx.ErrorCount(), sb.String())
+		require.Contains(t, sb.String(), "root.mode: ")
+		require.Contains(t, sb.String(), "root.location: ")
+		require.Contains(t, sb.String(), "root.func: 6:3: no function \"missing\" defined")
+	})
+}
+
+// walk returns an object and everything it contains, looking inside both
+// containers and the renderers of widgets.
+func walk(obj fyne.CanvasObject) []fyne.CanvasObject {
+	out := []fyne.CanvasObject{obj}
+	switch o := obj.(type) {
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			out = append(out, walk(child)...)
+		}
+	case fyne.Widget:
+		for _, child := range test.WidgetRenderer(o).Objects() {
+			out = append(out, walk(child)...)
+		}
+	}
+	return out
+}
+
+// waitForBindings waits for the listeners of bindings which have already been
+// notified, as fyne calls them in order on a goroutine of its own.
+func waitForBindings() {
+	done := make(chan struct{})
+	binding.NewBool().AddListener(binding.NewDataListener(func() {
+		close(done)
+	}))
+	<-done
+}
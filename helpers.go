@@ -1,6 +1,9 @@
 package fyneloader
 
 import (
+	"image/color"
+	"strings"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/storage"
@@ -11,6 +14,19 @@ import (
 	"github.com/tvarney/maputil/unpack"
 )
 
+// The values accepted by the enumerated keys of the built-in elements.
+var (
+	buttonAlignValues   = []string{ValueDefault, ValueCenter, ValueLeading, ValueTrailing}
+	filePickerModes     = []string{ValueOpen, ValueSave, ValueFolder}
+	iconPlacementValues = []string{ValueDefault, ValueLeading, ValueTrailing}
+	imageFillValues     = []string{ValueDefault, ValueStretch, ValueContain, ValueOriginal}
+	importanceValues    = []string{ValueDefault, ValueLow, ValueMedium, ValueHigh}
+	orientationValues   = []string{ValueDefault, ValueHorizontal, ValueVertical}
+	textAlignValues     = []string{ValueDefault, ValueLeading, ValueCenter, ValueTrailing}
+	textStyleValues     = []string{ValueDefault, "bold", "italic", "monospace", "bold+italic", "italic+bold"}
+	textWrapValues      = []string{ValueDefault, ValueOff, ValueTruncate, ValueBreak, ValueWord}
+)
+
 // GetChild fetches the value for the 'child' key and attempts to unpack it as
 // an element.
 func (l *Loader) GetChild(ctx *errctx.Context, data map[string]interface{}) fyne.CanvasObject {
@@ -34,8 +50,7 @@ func (l *Loader) GetImage(ctx *errctx.Context, data map[string]interface{}) *can
 	ctx.ErrorWithKey(err, KeyImageURI)
 
 	imgfill, err := GetStringEnumAsInt(
-		data, KeyImageFill,
-		[]string{ValueDefault, ValueStretch, ValueContain, ValueOriginal},
+		data, KeyImageFill, imageFillValues,
 		[]int{
 			int(canvas.ImageFillStretch), int(canvas.ImageFillStretch),
 			int(canvas.ImageFillContain), int(canvas.ImageFillOriginal),
@@ -68,79 +83,48 @@ func (l *Loader) GetImage(ctx *errctx.Context, data map[string]interface{}) *can
 	return img
 }
 
-// GetFnBoolToVoid fetches a func(bool) from the registered functions in the
-// loader.
-func GetFnBoolToVoid(l *Loader, data map[string]interface{}, key string) (func(bool), error) {
-	fnname, ok, err := maputil.GetString(data, key)
-	if err != nil || !ok {
-		return nil, err
+// GetColor fetches and parses a color from the map, returning def if the key is
+// not present.
+func GetColor(ctx *errctx.Context, data map[string]interface{}, key string, def color.Color) color.Color {
+	s := unpack.OptionalString(ctx, data, key, "")
+	if s == "" {
+		return def
 	}
-	fni, err := l.GetFunc(fnname)
+	c, err := ParseColor(s)
 	if err != nil {
-		return nil, err
+		ctx.ErrorWithKey(err, key)
+		return def
 	}
+	return c
+}
 
-	fn, ok := fni.(func(bool))
-	if !ok {
-		return nil, FunctionTypeError{Func: fni}
-	}
-	return fn, nil
+// GetFnBoolToVoid fetches a func(bool) from the registered functions in the
+// loader.
+func GetFnBoolToVoid(l *Loader, data map[string]interface{}, key string) (func(bool), error) {
+	return GetFn[func(bool)](l, data, key)
 }
 
 // GetFnFloat64ToVoid fetches a func(float64) from the registered functions in
 // the loader.
 func GetFnFloat64ToVoid(l *Loader, data map[string]interface{}, key string) (func(float64), error) {
-	fnname, ok, err := maputil.GetString(data, key)
-	if err != nil || !ok {
-		return nil, err
-	}
-	fni, err := l.GetFunc(fnname)
-	if err != nil {
-		return nil, err
-	}
-
-	fn, ok := fni.(func(float64))
-	if !ok {
-		return nil, FunctionTypeError{Func: fni}
-	}
-	return fn, nil
+	return GetFn[func(float64)](l, data, key)
 }
 
 // GetFnStringToVoid fetches a func(string) from the registered functions in the
 // loader.
 func GetFnStringToVoid(l *Loader, data map[string]interface{}, key string) (func(string), error) {
-	fnname, ok, err := maputil.GetString(data, key)
-	if err != nil || !ok {
-		return nil, err
-	}
-	fni, err := l.GetFunc(fnname)
-	if err != nil {
-		return nil, err
-	}
+	return GetFn[func(string)](l, data, key)
+}
 
-	fn, ok := fni.(func(string))
-	if !ok {
-		return nil, FunctionTypeError{Func: fni}
-	}
-	return fn, nil
+// GetFnURIToVoid fetches a func(fyne.URI) from the registered functions in the
+// loader.
+func GetFnURIToVoid(l *Loader, data map[string]interface{}, key string) (func(fyne.URI), error) {
+	return GetFn[func(fyne.URI)](l, data, key)
 }
 
 // GetFnVoidToVoid fetches a func() from the registered functions in the loader.
 func GetFnVoidToVoid(l *Loader, data map[string]interface{}, key string) (func(), error) {
-	fnname, ok, err := maputil.GetString(data, key)
-	if err != nil || !ok {
-		return nil, err
-	}
-	fni, err := l.GetFunc(fnname)
-	if err != nil {
-		return nil, err
-	}
-
-	fn, ok := fni.(func())
-	if !ok {
-		return nil, FunctionTypeError{Func: fni}
-	}
-	return fn, nil
+	return GetFn[func()](l, data, key)
 }
 
 // GetStringEnumAsInt fetches a string value from the map and converts it to an
@@ -191,9 +175,7 @@ func GetStringFromArray(data map[string]interface{}, key string, opts []string)
 // GetTextStyle fetches and interprets a string from the map as a text style.
 func GetTextStyle(ctx *errctx.Context, data map[string]interface{}, key string) fyne.TextStyle {
 	value := unpack.OptionalStringEnum(
-		ctx, data, key, []string{
-			ValueDefault, "bold", "italic", "monospace", "bold+italic", "italic+bold",
-		}, ValueDefault,
+		ctx, data, key, textStyleValues, ValueDefault,
 	)
 	switch value {
 	default:
@@ -215,9 +197,7 @@ func GetTextStyle(ctx *errctx.Context, data map[string]interface{}, key string)
 // alignment.
 func GetButtonAlign(ctx *errctx.Context, data map[string]interface{}) widget.ButtonAlign {
 	value := unpack.OptionalStringEnum(
-		ctx, data, KeyAlign, []string{
-			ValueDefault, ValueCenter, ValueLeading, ValueTrailing,
-		}, ValueDefault,
+		ctx, data, KeyAlign, buttonAlignValues, ValueDefault,
 	)
 	switch value {
 	default:
@@ -235,9 +215,7 @@ func GetButtonAlign(ctx *errctx.Context, data map[string]interface{}) widget.But
 // button icon placement value.
 func GetButtonIconPlacement(ctx *errctx.Context, data map[string]interface{}) widget.ButtonIconPlacement {
 	value := unpack.OptionalStringEnum(
-		ctx, data, KeyIconPlace, []string{
-			ValueDefault, ValueLeading, ValueTrailing,
-		}, ValueDefault,
+		ctx, data, KeyIconPlace, iconPlacementValues, ValueDefault,
 	)
 	switch value {
 	default:
@@ -253,9 +231,7 @@ func GetButtonIconPlacement(ctx *errctx.Context, data map[string]interface{}) wi
 // button importance value.
 func GetButtonImportance(ctx *errctx.Context, data map[string]interface{}) widget.ButtonImportance {
 	value := unpack.OptionalStringEnum(
-		ctx, data, KeyImportance, []string{
-			ValueDefault, ValueLow, ValueMedium, ValueHigh,
-		}, ValueDefault,
+		ctx, data, KeyImportance, importanceValues, ValueDefault,
 	)
 	switch value {
 	default:
@@ -273,9 +249,7 @@ func GetButtonImportance(ctx *errctx.Context, data map[string]interface{}) widge
 // alignment.
 func GetTextAlign(ctx *errctx.Context, data map[string]interface{}) fyne.TextAlign {
 	value := unpack.OptionalStringEnum(
-		ctx, data, KeyAlign, []string{
-			ValueDefault, ValueLeading, ValueCenter, ValueTrailing,
-		}, ValueDefault,
+		ctx, data, KeyAlign, textAlignValues, ValueDefault,
 	)
 	switch value {
 	default:
@@ -293,9 +267,7 @@ func GetTextAlign(ctx *errctx.Context, data map[string]interface{}) fyne.TextAli
 // value.
 func GetTextWrap(ctx *errctx.Context, data map[string]interface{}) fyne.TextWrap {
 	value := unpack.OptionalStringEnum(
-		ctx, data, KeyWrap, []string{
-			ValueDefault, ValueOff, ValueTruncate, ValueBreak, ValueWord,
-		}, ValueDefault,
+		ctx, data, KeyWrap, textWrapValues, ValueDefault,
 	)
 	switch value {
 	default:
@@ -315,9 +287,7 @@ func GetTextWrap(ctx *errctx.Context, data map[string]interface{}) fyne.TextWrap
 // orientation value.
 func GetOrientation(ctx *errctx.Context, data map[string]interface{}, defval widget.Orientation) widget.Orientation {
 	value := unpack.OptionalStringEnum(
-		ctx, data, KeyOrientation, []string{
-			ValueDefault, ValueHorizontal, ValueVertical,
-		}, ValueDefault,
+		ctx, data, KeyOrientation, orientationValues, ValueDefault,
 	)
 	switch value {
 	default:
@@ -330,3 +300,26 @@ func GetOrientation(ctx *errctx.Context, data map[string]interface{}, defval wid
 		return widget.Vertical
 	}
 }
+
+// getExtensions reads a list of file extensions, adding the leading dot to
+// those which have none. Empty extensions are reported and dropped.
+func getExtensions(ctx *errctx.Context, data map[string]interface{}, key string) []string {
+	exts := unpack.OptionalStringArray(ctx, data, key)
+	if exts == nil {
+		return nil
+	}
+	ctx.Path.Add(mpath.Key(key))
+	defer ctx.Path.Pop()
+	result := make([]string, 0, len(exts))
+	for i, ext := range exts {
+		switch {
+		case ext == "" || ext == ".":
+			ctx.ErrorWithIndex(ErrInvalidOption, i)
+		case strings.HasPrefix(ext, "."):
+			result = append(result, ext)
+		default:
+			result = append(result, "."+ext)
+		}
+	}
+	return result
+}
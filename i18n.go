@@ -0,0 +1,302 @@
+package fyneloader
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// translationPrefix marks a string value as a translation ID. A literal
+// leading `@` may be written as `@@`.
+const translationPrefix = "@"
+
+// textKeys lists the keys whose string values are text shown to the user, and
+// so may be translation IDs; the strings of any other key are never translated.
+var textKeys = map[string]struct{}{
+	KeyConfirm:     {},
+	KeyDismiss:     {},
+	KeyHint:        {},
+	KeyLabel:       {},
+	KeyMessage:     {},
+	KeyOptions:     {},
+	KeyPlaceHolder: {},
+	KeySelected:    {},
+	KeySubTitle:    {},
+	KeyText:        {},
+	KeyTitle:       {},
+}
+
+// pluralForms lists the forms a translation may give for plural messages.
+var pluralForms = []string{ValueZero, ValueOne, ValueOther}
+
+// translation is a single catalog entry, holding the message for each plural
+// form. Messages without plural forms are stored as the other form.
+type translation map[string]string
+
+// AddCatalog adds the translations for the given locale.
+//
+// Catalog entries are either a message, an object giving the zero, one and
+// other plural forms of a message, or an object of nested entries whose IDs are
+// prefixed by the key of the object and a dot. Entries replace any previously
+// added entry with the same ID.
+func (l *Loader) AddCatalog(ctx *errctx.Context, locale string, data map[string]interface{}) {
+	if ctx == nil {
+		ctx = errctx.New()
+	}
+	catalog, ok := l.catalogs[locale]
+	if !ok {
+		catalog = map[string]translation{}
+		l.catalogs[locale] = catalog
+	}
+	readCatalog(ctx, catalog, "", data)
+}
+
+// ReadCatalogFile reads the translations for the given locale from a YAML or
+// JSON file.
+func (l *Loader) ReadCatalogFile(ctx *errctx.Context, locale, path string) error {
+	data, pos, err := decodeFile(path)
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = errctx.New()
+	}
+	defer pos.install(ctx)()
+	l.AddCatalog(ctx, locale, data)
+	return nil
+}
+
+func readCatalog(ctx *errctx.Context, catalog map[string]translation, prefix string, data map[string]interface{}) {
+	for k, v := range data {
+		id := prefix + k
+		switch d := v.(type) {
+		case string:
+			catalog[id] = translation{ValueOther: d}
+		case map[string]interface{}:
+			ctx.Path.Add(mpath.Key(k))
+			if isPlural(d) {
+				t := translation{}
+				for _, form := range pluralForms {
+					if s := unpack.OptionalString(ctx, d, form, ""); s != "" {
+						t[form] = s
+					}
+				}
+				catalog[id] = t
+			} else {
+				readCatalog(ctx, catalog, id+".", d)
+			}
+			ctx.Path.Pop()
+		default:
+			ctx.ErrorWithKey(maputil.InvalidTypeError{
+				Actual:   maputil.TypeName(v),
+				Expected: []string{maputil.TypeString, maputil.TypeObject},
+			}, k)
+		}
+	}
+}
+
+// isPlural returns true if the given catalog object gives the plural forms of
+// a message rather than nested entries.
+func isPlural(data map[string]interface{}) bool {
+	if _, ok := data[ValueOther]; !ok {
+		return false
+	}
+	for k := range data {
+		if maputil.CheckEnum(k, pluralForms) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// locales returns the locales searched for translations, in order.
+//
+// A regional locale such as `fr-CA` falls back to its language `fr`, and all
+// locales fall back to the default locale.
+func (l *Loader) locales() []string {
+	locale := l.Locale
+	if locale == "" {
+		locale = l.DefaultLocale
+	}
+	locales := []string{locale}
+	if idx := strings.IndexAny(locale, "-_"); idx > 0 {
+		locales = append(locales, locale[:idx])
+	}
+	if l.DefaultLocale != "" && l.DefaultLocale != locale {
+		locales = append(locales, l.DefaultLocale)
+	}
+	return locales
+}
+
+// Translate returns the message with the given ID in the current locale.
+//
+// If args contains a numeric count, the plural form of the message is chosen
+// from it. Each `{name}` within the message is replaced by the argument with
+// that name. If no catalog contains the ID, a warning is reported through the
+// context and false is returned.
+func (l *Loader) Translate(ctx *errctx.Context, id string, args map[string]interface{}) (string, bool) {
+	if ctx == nil {
+		ctx = errctx.New()
+	}
+	locales := l.locales()
+	var t translation
+	for _, locale := range locales {
+		var ok bool
+		if t, ok = l.catalogs[locale][id]; ok {
+			break
+		}
+	}
+	if t == nil {
+		ctx.Error(Warning{Err: MissingTranslationError{ID: id, Locale: locales[0]}})
+		return "", false
+	}
+
+	msg := t[ValueOther]
+	if count, ok := args[KeyCount]; ok {
+		if n, err := maputil.AsNumber(count); err == nil {
+			msg = t.plural(n)
+		}
+	}
+	return formatMessage(msg, args), true
+}
+
+func (t translation) plural(count float64) string {
+	form := ValueOther
+	switch count {
+	case 0:
+		form = ValueZero
+	case 1:
+		form = ValueOne
+	}
+	if msg, ok := t[form]; ok {
+		return msg
+	}
+	return t[ValueOther]
+}
+
+// formatMessage replaces each `{name}` in msg with the argument of that name.
+// Placeholders without a matching argument are left as-is.
+func formatMessage(msg string, args map[string]interface{}) string {
+	if len(args) == 0 || !strings.Contains(msg, "{") {
+		return msg
+	}
+	pairs := make([]string, 0, 2*len(args))
+	for k, v := range args {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
+
+// translateString resolves a string value which references a translation.
+func (l *Loader) translateString(ctx *errctx.Context, s string) (string, bool) {
+	if !strings.HasPrefix(s, translationPrefix) {
+		return s, false
+	}
+	if strings.HasPrefix(s, translationPrefix+translationPrefix) {
+		return s[len(translationPrefix):], false
+	}
+	if msg, ok := l.Translate(ctx, s[len(translationPrefix):], nil); ok {
+		return msg, true
+	}
+	return s, true
+}
+
+// translateValue resolves the value of a variable used as text, which may be a
+// translation ID or object.
+func (l *Loader) translateValue(ctx *errctx.Context, v interface{}) interface{} {
+	switch d := v.(type) {
+	case string:
+		s, _ := l.translateString(ctx, d)
+		return s
+	case map[string]interface{}:
+		if isTranslation(d) {
+			return l.translateObject(ctx, d)
+		}
+	}
+	return v
+}
+
+// translateObject resolves an object value of the form
+// `{"@": id, count: n, args: {...}}`.
+func (l *Loader) translateObject(ctx *errctx.Context, data map[string]interface{}) interface{} {
+	id := unpack.RequireString(ctx, data, KeyTranslation)
+	args := unpack.OptionalObject(ctx, data, KeyArgs, nil)
+	if count, ok := data[KeyCount]; ok {
+		if _, err := maputil.AsNumber(count); err != nil {
+			ctx.ErrorWithKey(err, KeyCount)
+		} else {
+			merged := make(map[string]interface{}, len(args)+1)
+			for k, v := range args {
+				merged[k] = v
+			}
+			merged[KeyCount] = count
+			args = merged
+		}
+	}
+	if id == "" {
+		return nil
+	}
+	ctx.Path.Add(mpath.Key(KeyTranslation))
+	msg, ok := l.Translate(ctx, id, args)
+	ctx.Path.Pop()
+	if !ok {
+		return translationPrefix + id
+	}
+	return msg
+}
+
+func isTranslation(data map[string]interface{}) bool {
+	_, ok := data[KeyTranslation]
+	return ok
+}
+
+// TranslationIDs returns the sorted IDs of all translations referenced by the
+// given definition.
+//
+// All strings in the vars section are searched, as variables may be used as
+// text. The theme section is not searched, as its values are never translated.
+func TranslationIDs(data map[string]interface{}) []string {
+	found := map[string]struct{}{}
+	for k, v := range data {
+		if k == KeyTheme {
+			continue
+		}
+		findTranslationIDs(v, found, k == KeyVars, k == KeyVars)
+	}
+	ids := make([]string, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// findTranslationIDs adds the translation IDs within v to found. Strings are
+// only searched if they are text, or anywhere within vars.
+func findTranslationIDs(v interface{}, found map[string]struct{}, text, vars bool) {
+	switch d := v.(type) {
+	case string:
+		if text && strings.HasPrefix(d, translationPrefix) && !strings.HasPrefix(d, translationPrefix+translationPrefix) {
+			found[d[len(translationPrefix):]] = struct{}{}
+		}
+	case map[string]interface{}:
+		if id, ok := d[KeyTranslation].(string); ok {
+			found[id] = struct{}{}
+		}
+		for k, value := range d {
+			if k != KeyTranslation {
+				_, textKey := textKeys[k]
+				findTranslationIDs(value, found, vars || textKey, vars)
+			}
+		}
+	case []interface{}:
+		for _, value := range d {
+			findTranslationIDs(value, found, text, vars)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package fyneloader_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTranslations(t *testing.T) {
+	t.Parallel()
+
+	const doc = `
+vars:
+  user: Sam
+root:
+  type: vbox
+  children:
+  - {type: label, text: "@greeting.hello"}
+  - type: label
+    text: {"@": greeting.welcome, args: {name: "${user}"}}
+  - type: label
+    text: {"@": inbox, count: 1}
+  - type: label
+    text: {"@": inbox, count: 5}
+  - type: label
+    text: {"@": inbox, count: 0}
+  - {type: label, text: "@@handle"}
+  - {type: button, text: "@missing"}
+`
+	newLoader := func(t *testing.T, locale string) *fyneloader.Loader {
+		t.Helper()
+		l := fyneloader.New()
+		l.Locale = locale
+		ctx := errctx.New()
+		l.AddCatalog(ctx, "en", map[string]interface{}{
+			"greeting": map[string]interface{}{
+				"hello":   "Hello",
+				"welcome": "Welcome, {name}!",
+			},
+			"inbox": map[string]interface{}{
+				"zero":  "No messages",
+				"one":   "One message",
+				"other": "{count} messages",
+			},
+		})
+		require.Zero(t, ctx.ErrorCount())
+
+		path := filepath.Join(t.TempDir(), "fr.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("greeting:\n  hello: Bonjour\n"), 0o600))
+		require.NoError(t, l.ReadCatalogFile(ctx, "fr", path))
+		require.Zero(t, ctx.ErrorCount())
+		return l
+	}
+	texts := func(roots map[string]fyne.CanvasObject) []string {
+		var texts []string
+		for _, obj := range roots["root"].(*fyne.Container).Objects {
+			switch w := obj.(type) {
+			case *widget.Label:
+				texts = append(texts, w.Text)
+			case *widget.Button:
+				texts = append(texts, w.Text)
+			}
+		}
+		return texts
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+		ctx := errctx.New()
+		roots, err := newLoader(t, "").ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"Hello", "Welcome, Sam!", "One message", "5 messages", "No messages", "@handle", "@missing",
+		}, texts(roots))
+
+		require.Equal(t, 1, ctx.ErrorCount())
+		var warning fyneloader.Warning
+		require.True(t, errors.As(ctx.LastError(), &warning))
+		require.Equal(t, fyneloader.MissingTranslationError{ID: "missing", Locale: "en"}, warning.Err)
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		t.Parallel()
+		ctx := errctx.New()
+		roots, err := newLoader(t, "fr-CA").ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Equal(t, "Bonjour", texts(roots)[0])
+		require.Equal(t, "Welcome, Sam!", texts(roots)[1])
+		require.Equal(t, fyneloader.Warning{
+			Err: fyneloader.MissingTranslationError{ID: "missing", Locale: "fr-CA"},
+		}, ctx.LastError())
+	})
+	t.Run("TextKeys", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+vars:
+  hello: "@greeting.hello"
+  inbox: {"@": inbox, count: 0}
+root:
+  type: vbox
+  children:
+  - {type: label, id: "@status", text: "${hello}"}
+  - {type: label, text: "${inbox}"}
+  - {type: label, text: "${hello}!"}
+  - {type: button, text: Hide, func: [{hide: "@status"}]}
+`
+		ctx := errctx.New()
+		l := newLoader(t, "")
+		loaded, err := l.LoadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Equal(t, []string{"Hello", "No messages", "@greeting.hello!", "Hide"}, texts(loaded.Roots))
+		box := loaded.Roots["root"].(*fyne.Container)
+		box.Objects[3].(*widget.Button).OnTapped()
+		require.False(t, box.Objects[0].Visible())
+
+		var data map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &data))
+		require.Equal(t, []string{"greeting.hello", "inbox"}, fyneloader.TranslationIDs(data))
+	})
+	t.Run("IDs", func(t *testing.T) {
+		t.Parallel()
+		var data map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &data))
+		require.Equal(
+			t,
+			[]string{"greeting.hello", "greeting.welcome", "inbox", "missing"},
+			fyneloader.TranslationIDs(data),
+		)
+	})
+}
@@ -0,0 +1,134 @@
+package fyneloader
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+)
+
+// lookupFn is the function type used to resolve references during
+// interpolation.
+type lookupFn func(string) (interface{}, bool)
+
+// interpolate expands all `${name}` references in the given string.
+//
+// If the string consists of exactly one reference, the referenced value is
+// returned as-is so that non-string values keep their type. A literal `${` may
+// be written as `$${`. On error, the string is returned unchanged.
+func interpolate(s string, lookup lookupFn) (interface{}, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	if name, ok := singleReference(s); ok {
+		v, ok := lookup(name)
+		if !ok {
+			return s, UndefinedReferenceError{Name: name}
+		}
+		return v, nil
+	}
+
+	builder := &strings.Builder{}
+	rest := s
+	for {
+		idx := strings.Index(rest, "${")
+		if idx < 0 {
+			builder.WriteString(rest)
+			break
+		}
+		if idx > 0 && rest[idx-1] == '$' {
+			builder.WriteString(rest[:idx-1])
+			builder.WriteString("${")
+			rest = rest[idx+2:]
+			continue
+		}
+		end := strings.Index(rest[idx:], "}")
+		if end < 0 {
+			return s, ErrUnterminatedReference
+		}
+		name := strings.TrimSpace(rest[idx+2 : idx+end])
+		v, ok := lookup(name)
+		if !ok {
+			return s, UndefinedReferenceError{Name: name}
+		}
+		builder.WriteString(rest[:idx])
+		if v != nil {
+			fmt.Fprint(builder, v)
+		}
+		rest = rest[idx+end+1:]
+	}
+	return builder.String(), nil
+}
+
+// singleReference returns the name of the reference if the given string
+// consists of exactly one reference.
+func singleReference(s string) (string, bool) {
+	if strings.HasPrefix(s, "${") && strings.Index(s, "}") == len(s)-1 {
+		return strings.TrimSpace(s[2 : len(s)-1]), true
+	}
+	return "", false
+}
+
+// substitute returns a copy of v with all references in string values
+// expanded and all translations resolved.
+//
+// Errors are sent to the context at the path of the offending value. Map
+// entries which expand to nil are removed from the copy. The templates of
+// repeat elements are copied without expansion, as they are expanded once for
+// each item when the repeat element itself is unpacked.
+func (l *Loader) substitute(ctx *errctx.Context, v interface{}, lookup lookupFn) interface{} {
+	return l.substituteValue(ctx, v, lookup, false)
+}
+
+// substituteValue implements substitute. Translation IDs are only resolved in
+// the strings of text keys, including those given by a single reference.
+func (l *Loader) substituteValue(ctx *errctx.Context, v interface{}, lookup lookupFn, text bool) interface{} {
+	switch d := v.(type) {
+	case string:
+		if !text {
+			r, err := interpolate(d, lookup)
+			ctx.Error(err)
+			return r
+		}
+		s, translated := l.translateString(ctx, d)
+		if translated {
+			return s
+		}
+		r, err := interpolate(s, lookup)
+		ctx.Error(err)
+		if _, ok := singleReference(s); ok && err == nil {
+			return l.translateValue(ctx, r)
+		}
+		return r
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(d))
+		repeat := isRepeat(d)
+		for k, value := range d {
+			if repeat && k == KeyTemplate {
+				m[k] = value
+				continue
+			}
+			ctx.Path.Add(mpath.Key(k))
+			_, textKey := textKeys[k]
+			r := l.substituteValue(ctx, value, lookup, textKey)
+			ctx.Path.Pop()
+			if r != nil || value == nil {
+				m[k] = r
+			}
+		}
+		if isTranslation(d) {
+			return l.translateObject(ctx, m)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, 0, len(d))
+		for i, value := range d {
+			ctx.Path.Add(mpath.Index(i))
+			a = append(a, l.substituteValue(ctx, value, lookup, text))
+			ctx.Path.Pop()
+		}
+		return a
+	}
+	return v
+}
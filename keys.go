@@ -3,50 +3,162 @@ package fyneloader
 // Key constants define the JSON/YAML syntax that the loader accepts.
 const (
 	KeyAlign       = "align"
+	KeyArgs        = "args"
+	KeyAnimations  = "animations"
+	KeyAs          = "as"
+	KeyAutoReverse = "auto-reverse"
+	KeyAutoStart   = "auto-start"
+	KeyBind        = "bind"
+	KeyChecked     = "checked"
 	KeyChild       = "child"
 	KeyChildren    = "children"
+	KeyClass       = "class"
+	KeyColor       = "color"
+	KeyColors      = "colors"
+	KeyComponents  = "components"
+	KeyConfirm     = "confirm"
+	KeyContent     = "content"
+	KeyCount       = "count"
+	KeyCurve       = "curve"
+	KeyData        = "data"
+	KeyDefault     = "default"
+	KeyDefaults    = "defaults"
+	KeyDialogs     = "dialogs"
 	KeyDisabled    = "disabled"
+	KeyDismiss     = "dismiss"
+	KeyDuration    = "duration"
+	KeyFileName    = "file-name"
+	KeyFilter      = "filter"
+	KeyFixedSize   = "fixed-size"
+	KeyFonts       = "fonts"
+	KeyFrom        = "from"
+	KeyFullScreen  = "full-screen"
 	KeyFunc        = "func"
+	KeyHeight      = "height"
 	KeyHidden      = "hidden"
+	KeyHint        = "hint"
+	KeyID          = "id"
 	KeyIconPlace   = "icon-placement"
+	KeyIcons       = "icons"
+	KeyIf          = "if"
 	KeyImageFill   = "image-fill"
 	KeyImagePath   = "image-path"
 	KeyImageURI    = "image-uri"
 	KeyImportance  = "importance"
 	KeyItems       = "items"
+	KeyLabel       = "label"
+	KeyMainMenu    = "main-menu"
+	KeyMainMenus   = "main-menus"
+	KeyMaster      = "master"
+	KeyLocation    = "location"
 	KeyMax         = "max"
+	KeyMenus       = "menus"
+	KeyMessage     = "message"
 	KeyMin         = "min"
+	KeyMinSize     = "min-size"
+	KeyMode        = "mode"
+	KeyMultiLine   = "multi-line"
 	KeyMultiOpen   = "multi-open"
+	KeyOnConfirm   = "on-confirm"
+	KeyOnDismiss   = "on-dismiss"
+	KeyOnSubmit    = "on-submit"
 	KeyOpen        = "open"
 	KeyOptions     = "options"
 	KeyOrientation = "orientation"
+	KeyPadded      = "padded"
+	KeyParams      = "params"
+	KeyPassword    = "password"
+	KeyPlaceHolder = "placeholder"
+	KeyPreference  = "preference"
+	KeyProperty    = "property"
+	KeyQuit        = "quit"
+	KeyRepeat      = "repeat"
 	KeyRequired    = "required"
 	KeySelected    = "selected"
+	KeyShortcut    = "shortcut"
+	KeyShortcuts   = "shortcuts"
+	KeySize        = "size"
+	KeySizes       = "sizes"
 	KeyStep        = "step"
+	KeyStrokeColor = "stroke-color"
+	KeyStrokeWidth = "stroke-width"
 	KeyStyle       = "style"
+	KeyStyles      = "styles"
 	KeySubTitle    = "subtitle"
+	KeyTarget      = "target"
+	KeyTemplate    = "template"
 	KeyText        = "text"
+	KeyTheme       = "theme"
 	KeyTitle       = "title"
+	KeyTo          = "to"
+	KeyTranslation = "@"
 	KeyType        = "type"
+	KeyVars        = "vars"
+	KeyWidth       = "width"
+	KeyWindows     = "windows"
+	KeyX           = "x"
+	KeyY           = "y"
 	KeyWrap        = "wrap"
 )
 
+// Action constants define the names of the built-in declarative actions.
+const (
+	ActionCall           = "call"
+	ActionDisable        = "disable"
+	ActionEnable         = "enable"
+	ActionHide           = "hide"
+	ActionNavigate       = "navigate"
+	ActionSetText        = "set-text"
+	ActionShow           = "show"
+	ActionStartAnimation = "start-animation"
+	ActionStopAnimation  = "stop-animation"
+)
+
 // Value constants define constant values that the loader accepts.
 const (
-	ValueBreak      = "break"
-	ValueCenter     = "center"
-	ValueContain    = "contain"
-	ValueDefault    = "default"
-	ValueHigh       = "high"
-	ValueHorizontal = "horizontal"
-	ValueLeading    = "leading"
-	ValueLow        = "low"
-	ValueMedium     = "medium"
-	ValueOff        = "off"
-	ValueOriginal   = "original"
-	ValueStretch    = "stretch"
-	ValueTrailing   = "trailing"
-	ValueTruncate   = "truncate"
-	ValueVertical   = "vertical"
-	ValueWord       = "word"
+	ValueBold        = "bold"
+	ValueBoldItalic  = "bold-italic"
+	ValueBreak       = "break"
+	ValueCenter      = "center"
+	ValueColor       = "color"
+	ValueConfirm     = "confirm"
+	ValueContain     = "contain"
+	ValueCustom      = "custom"
+	ValueDark        = "dark"
+	ValueDefault     = "default"
+	ValueEaseIn      = "ease-in"
+	ValueEaseInOut   = "ease-in-out"
+	ValueEaseOut     = "ease-out"
+	ValueFolder      = "folder"
+	ValueForm        = "form"
+	ValueHigh        = "high"
+	ValueHorizontal  = "horizontal"
+	ValueIndex       = "index"
+	ValueInformation = "information"
+	ValueItalic      = "italic"
+	ValueLeading     = "leading"
+	ValueLight       = "light"
+	ValueLinear      = "linear"
+	ValueLow         = "low"
+	ValueMedium      = "medium"
+	ValueMonospace   = "monospace"
+	ValueOff         = "off"
+	ValueOpen        = "open"
+	ValueOne         = "one"
+	ValueOpacity     = "opacity"
+	ValueOriginal    = "original"
+	ValueOther       = "other"
+	ValuePosition    = "position"
+	ValueRegular     = "regular"
+	ValueRepeat      = "repeat"
+	ValueSave        = "save"
+	ValueSeparator   = "separator"
+	ValueSize        = "size"
+	ValueSlot        = "slot"
+	ValueStretch     = "stretch"
+	ValueTrailing    = "trailing"
+	ValueTruncate    = "truncate"
+	ValueVertical    = "vertical"
+	ValueWord        = "word"
+	ValueZero        = "zero"
 )
@@ -1,7 +1,6 @@
 package fyneloader
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +9,7 @@ import (
 	"strings"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
 	"github.com/tvarney/maputil"
 	"github.com/tvarney/maputil/errctx"
 	"github.com/tvarney/maputil/mpath"
@@ -23,28 +23,117 @@ type CreateElementFn func(*errctx.Context, *Loader, map[string]interface{}) fyne
 // Loader allows for loading UI definitions at runtime.
 type Loader struct {
 	FetchURIs bool
-	callbacks map[string]interface{}
-	elements  map[string]CreateElementFn
+
+	// OnNavigate is called by the navigate action with the name of the root
+	// element to navigate to.
+	OnNavigate func(string, fyne.CanvasObject)
+
+	// OnCallbackError is called with the name of a registered function and the
+	// error it returned, for functions returning an error which are used where
+	// no result is expected. If nil, the error is logged.
+	OnCallbackError func(string, error)
+
+	// EnvVars enables referencing environment variables as `${env.NAME}`
+	// within definition files.
+	EnvVars bool
+
+	// Locale is the locale used to resolve translations. If empty, the
+	// default locale is used.
+	Locale string
+
+	// DefaultLocale is the locale used for translations which are missing
+	// from the catalogs of the current locale.
+	DefaultLocale string
+
+	// Preferences is used by elements with a preference key to persist their
+	// values. If nil, the preferences of the current app are used.
+	Preferences fyne.Preferences
+
+	// Strict controls whether keys which are not accepted by the type of an
+	// element are reported.
+	Strict StrictMode
+
+	// Threshold is the lowest severity of diagnostic which causes Load to
+	// return an error. If zero, only errors do.
+	Threshold Severity
+
+	// MinSeverity is the lowest severity of diagnostic passed on to the
+	// handler of the context; less severe diagnostics are dropped.
+	MinSeverity Severity
+
+	// DevMode replaces elements which fail to load with a placeholder showing
+	// the error and the path of the element, rather than leaving them out.
+	DevMode bool
+
+	callbacks   map[string]interface{}
+	bindings    map[string]binding.DataItem
+	elements    map[string]CreateElementFn
+	descriptors map[string]*ElementDescriptor
+	actions     map[string]ActionFn
+	vars        map[string]interface{}
+	docVars     map[string]interface{}
+	data        map[string][]interface{}
+	catalogs    map[string]map[string]translation
+	components  map[string]*component
+	styles      map[string]map[string]interface{}
+	defaults    map[string]map[string]interface{}
+	expanding   []string
+	scopes      []map[string]interface{}
+	roots       map[string]fyne.CanvasObject
+	objects     map[string]fyne.CanvasObject
+	deferred    []deferredCheck
+	positions   positions
+	diagnostics *diagnosticHandler
+	animations  map[string]*Animation
+	alphas      map[fyne.CanvasObject]uint8
+	contents    map[string]struct{}
 }
 
 // New returns a new Loader instance.
 func New() *Loader {
 	return &Loader{
-		FetchURIs: false,
-		callbacks: map[string]interface{}{},
+		FetchURIs:     false,
+		EnvVars:       false,
+		Locale:        "",
+		DefaultLocale: "en",
+		callbacks:     map[string]interface{}{},
+		bindings:      map[string]binding.DataItem{},
+		vars:          map[string]interface{}{},
+		docVars:       map[string]interface{}{},
+		data:          map[string][]interface{}{},
+		catalogs:      map[string]map[string]translation{},
+		components:    map[string]*component{},
+		styles:        map[string]map[string]interface{}{},
+		defaults:      map[string]map[string]interface{}{},
+		descriptors:   builtinDescriptors(),
 		elements: map[string]CreateElementFn{
-			"accordion": CreateAccordion,
-			"button":    CreateButton,
-			"card":      CreateCard,
-			"check":     CreateCheck,
-			"hbox":      CreateHBox,
-			"hspacer":   CreateHSpacer,
-			"label":     CreateLabel,
-			"radio":     CreateRadioGroup,
-			"slider":    CreateSlider,
-			"spacer":    CreateSpacer,
-			"vbox":      CreateVBox,
-			"vspacer":   CreateVSpacer,
+			"accordion":   CreateAccordion,
+			"button":      CreateButton,
+			"card":        CreateCard,
+			"check":       CreateCheck,
+			"entry":       CreateEntry,
+			"file-picker": CreateFilePicker,
+			"hbox":        CreateHBox,
+			"hspacer":     CreateHSpacer,
+			"label":       CreateLabel,
+			"radio":       CreateRadioGroup,
+			"rectangle":   CreateRectangle,
+			"select":      CreateSelect,
+			"slider":      CreateSlider,
+			"spacer":      CreateSpacer,
+			"vbox":        CreateVBox,
+			"vspacer":     CreateVSpacer,
+		},
+		actions: map[string]ActionFn{
+			ActionCall:           actionCall,
+			ActionDisable:        actionDisable,
+			ActionEnable:         actionEnable,
+			ActionHide:           actionHide,
+			ActionNavigate:       actionNavigate,
+			ActionSetText:        actionSetText,
+			ActionShow:           actionShow,
+			ActionStartAnimation: actionStartAnimation,
+			ActionStopAnimation:  actionStopAnimation,
 		},
 	}
 }
@@ -53,8 +142,10 @@ func New() *Loader {
 //
 // If the function callback is nil and there already exists a callback for the
 // given name, the callback will be removed. This function will replace a
-// callback with no error if a name is repeated.
+// callback with no error if a name is repeated. In either case, any descriptor
+// of the previous callback is removed, as it may not describe the new one.
 func (l *Loader) RegisterElement(name string, fn CreateElementFn) {
+	delete(l.descriptors, name)
 	if fn == nil {
 		_, ok := l.elements[name]
 		if ok {
@@ -65,6 +156,17 @@ func (l *Loader) RegisterElement(name string, fn CreateElementFn) {
 	l.elements[name] = fn
 }
 
+// RegisterBinding registers a data binding which elements may be bound to by
+// name with the bind key. If a name is repeated, the binding is replaced; a
+// nil binding removes it.
+func (l *Loader) RegisterBinding(name string, data binding.DataItem) {
+	if data == nil {
+		delete(l.bindings, name)
+		return
+	}
+	l.bindings[name] = data
+}
+
 // RegisterFunc registers a new function available for use within generated
 // UI elements.
 //
@@ -81,6 +183,44 @@ func (l *Loader) RegisterFunc(name string, fn interface{}) error {
 	return nil
 }
 
+// RegisterMethods registers each exported method of obj as a function, named
+// `namespace.Method`, or just `Method` if the namespace is empty. Methods with
+// pointer receivers are only available if obj is a pointer.
+//
+// If any of the names is already registered, no methods are registered and a
+// DuplicateFunctionError listing the names is returned. A nil pointer is
+// rejected with ErrNilReceiver.
+func (l *Loader) RegisterMethods(namespace string, obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	if !v.IsValid() || v.NumMethod() == 0 {
+		return ErrNoMethods
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return ErrNilReceiver
+	}
+
+	prefix := ""
+	if namespace != "" {
+		prefix = namespace + "."
+	}
+	methods := make(map[string]interface{}, v.NumMethod())
+	var duplicates []string
+	for i := 0; i < v.NumMethod(); i++ {
+		name := prefix + v.Type().Method(i).Name
+		if _, ok := l.callbacks[name]; ok {
+			duplicates = append(duplicates, name)
+		}
+		methods[name] = v.Method(i).Interface()
+	}
+	if len(duplicates) > 0 {
+		return DuplicateFunctionError{Names: duplicates}
+	}
+	for name, fn := range methods {
+		l.callbacks[name] = fn
+	}
+	return nil
+}
+
 // GetFunc returns the function with the given name.
 //
 // If a function with the given name was not registered, this function will
@@ -93,6 +233,36 @@ func (l *Loader) GetFunc(name string) (interface{}, error) {
 	return fn, nil
 }
 
+// LoadFile reads a file as either YAML or JSON and returns the document loaded
+// from it.
+func (l *Loader) LoadFile(ctx *errctx.Context, path string) (*Document, error) {
+	data, pos, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return l.load(ctx, data, pos)
+}
+
+// LoadYAML takes a Reader, interprets it as YAML data, and returns the document
+// loaded from it.
+func (l *Loader) LoadYAML(ctx *errctx.Context, in io.Reader) (*Document, error) {
+	data, pos, err := decodeYAML(in)
+	if err != nil {
+		return nil, err
+	}
+	return l.load(ctx, data, pos)
+}
+
+// LoadJSON takes a Reader, interprets it as JSON data, and returns the document
+// loaded from it.
+func (l *Loader) LoadJSON(ctx *errctx.Context, in io.Reader) (*Document, error) {
+	data, pos, err := decodeJSON(in)
+	if err != nil {
+		return nil, err
+	}
+	return l.load(ctx, data, pos)
+}
+
 // ReadFile reads a file as either YAML or JSON.
 func (l *Loader) ReadFile(ctx *errctx.Context, path string) (map[string]fyne.CanvasObject, error) {
 	ext := strings.ToLower(filepath.Ext(path))
@@ -118,12 +288,11 @@ func (l *Loader) ReadFileYAML(ctx *errctx.Context, path string) (map[string]fyne
 
 // ReadYAML takes a Reader and interprets it as YAML data.
 func (l *Loader) ReadYAML(ctx *errctx.Context, in io.Reader) (map[string]fyne.CanvasObject, error) {
-	var generic map[string]interface{}
-	err := yaml.NewDecoder(in).Decode(&generic)
+	generic, pos, err := decodeYAML(in)
 	if err != nil {
 		return nil, err
 	}
-	return l.Unmarshal(ctx, generic)
+	return rootsOf(l.load(ctx, generic, pos))
 }
 
 // ReadFileJSON reads a file as a JSON definition file.
@@ -139,23 +308,100 @@ func (l *Loader) ReadFileJSON(ctx *errctx.Context, path string) (map[string]fyne
 
 // ReadJSON takes a Reader and interprets it as JSON data.
 func (l *Loader) ReadJSON(ctx *errctx.Context, in io.Reader) (map[string]fyne.CanvasObject, error) {
-	var generic map[string]interface{}
-	err := json.NewDecoder(in).Decode(&generic)
+	generic, pos, err := decodeJSON(in)
 	if err != nil {
 		return nil, err
 	}
-	return l.Unmarshal(ctx, generic)
+	return rootsOf(l.load(ctx, generic, pos))
 }
 
 // Unmarshal takes a YAML or JSON map and creates a map of widgets from it.
 func (l *Loader) Unmarshal(ctx *errctx.Context, data map[string]interface{}) (map[string]fyne.CanvasObject, error) {
+	return rootsOf(l.Load(ctx, data))
+}
+
+// rootsOf returns the root elements of a loaded document.
+func rootsOf(doc *Document, err error) (map[string]fyne.CanvasObject, error) {
+	if doc == nil {
+		return nil, err
+	}
+	return doc.Roots, err
+}
+
+// load creates a document from decoded data, reporting errors with the
+// positions at which they occurred.
+func (l *Loader) load(ctx *errctx.Context, data map[string]interface{}, pos positions) (*Document, error) {
+	l.positions = pos
+	defer func() { l.positions = nil }()
+	return l.Load(ctx, data)
+}
+
+// Load takes a YAML or JSON map and creates a document from it.
+//
+// Diagnostics are reported through the context as they occur. If any of them
+// are at least as severe as the threshold of the loader, the document is
+// returned along with a LoadError holding those diagnostics.
+func (l *Loader) Load(ctx *errctx.Context, data map[string]interface{}) (*Document, error) {
 	if ctx == nil {
 		// New empty context
 		ctx = errctx.New()
 	}
 	ctx.Reset()
+	diagnostics, restore := l.installDiagnostics(ctx)
+	defer restore()
+
+	l.docVars = map[string]interface{}{}
+	if raw, ok := data[KeyVars]; ok {
+		ctx.Path.Add(mpath.Key(KeyVars))
+		l.readVars(ctx, raw)
+		ctx.Path.Pop()
+	}
+
+	l.components = map[string]*component{}
+	l.expanding = nil
+	l.scopes = nil
+	if raw, ok := data[KeyComponents]; ok {
+		ctx.Path.Add(mpath.Key(KeyComponents))
+		l.readComponents(ctx, raw)
+		ctx.Path.Pop()
+	}
+
+	data = l.substituteVars(ctx, data)
+	l.styles = map[string]map[string]interface{}{}
+	if raw, ok := data[KeyStyles]; ok {
+		ctx.Path.Add(mpath.Key(KeyStyles))
+		l.readStyles(ctx, raw)
+		ctx.Path.Pop()
+	}
+	l.defaults = map[string]map[string]interface{}{}
+	if raw, ok := data[KeyDefaults]; ok {
+		ctx.Path.Add(mpath.Key(KeyDefaults))
+		l.readDefaults(ctx, raw)
+		ctx.Path.Pop()
+	}
+
+	doc := &Document{}
+	if raw, ok := data[KeyTheme]; ok {
+		ctx.Path.Add(mpath.Key(KeyTheme))
+		if m, err := maputil.AsObject(raw); err == nil {
+			doc.Theme = l.UnmarshalTheme(ctx, m)
+		} else {
+			ctx.Error(err)
+		}
+		ctx.Path.Pop()
+	}
+
 	widgets := make(map[string]fyne.CanvasObject, len(data))
+	l.roots = widgets
+	l.objects = map[string]fyne.CanvasObject{}
+	l.deferred = nil
+	l.animations = map[string]*Animation{}
+	l.alphas = map[fyne.CanvasObject]uint8{}
+	l.contents = map[string]struct{}{}
 	for k, v := range data {
+		if isSection(k) {
+			continue
+		}
 		ctx.Path.Add(mpath.Key(k))
 		w := l.Unpack(ctx, v)
 		if w != nil {
@@ -163,17 +409,77 @@ func (l *Loader) Unmarshal(ctx *errctx.Context, data map[string]interface{}) (ma
 		}
 		ctx.Path.Pop()
 	}
-	return widgets, nil
+
+	if raw, ok := data[KeyAnimations]; ok {
+		ctx.Path.Add(mpath.Key(KeyAnimations))
+		l.readAnimations(ctx, raw)
+		ctx.Path.Pop()
+	}
+	doc.Animations = l.animations
+	if raw, ok := data[KeyMenus]; ok {
+		ctx.Path.Add(mpath.Key(KeyMenus))
+		doc.Menus = l.readMenus(ctx, raw)
+		ctx.Path.Pop()
+	}
+	if raw, ok := data[KeyMainMenus]; ok {
+		ctx.Path.Add(mpath.Key(KeyMainMenus))
+		doc.MainMenus = l.readMainMenus(ctx, raw, doc.Menus)
+		ctx.Path.Pop()
+	}
+	doc.Roots = widgets
+	if raw, ok := data[KeyShortcuts]; ok {
+		ctx.Path.Add(mpath.Key(KeyShortcuts))
+		doc.Shortcuts = l.readShortcuts(ctx, raw)
+		ctx.Path.Pop()
+	}
+	if raw, ok := data[KeyDialogs]; ok {
+		ctx.Path.Add(mpath.Key(KeyDialogs))
+		doc.Dialogs = l.readDialogs(ctx, raw, doc.Roots)
+		ctx.Path.Pop()
+	}
+	if raw, ok := data[KeyWindows]; ok {
+		ctx.Path.Add(mpath.Key(KeyWindows))
+		doc.Windows = l.readWindows(ctx, raw, doc)
+		ctx.Path.Pop()
+	}
+	l.runDeferred(ctx)
+	if len(diagnostics.errs) > 0 {
+		return doc, LoadError{Errors: diagnostics.errs}
+	}
+	return doc, nil
 }
 
-// Unpack handles loading a single widget.
+// Unpack handles loading a single widget. In development mode, an element
+// which fails to load is replaced with a placeholder showing the error.
 func (l *Loader) Unpack(ctx *errctx.Context, v interface{}) fyne.CanvasObject {
+	if !l.DevMode {
+		return l.unpack(ctx, v)
+	}
+	diagnostics := l.diagnostics
+	if diagnostics == nil {
+		var restore func()
+		diagnostics, restore = l.installDiagnostics(ctx)
+		defer restore()
+	}
+	count := diagnostics.errCount
+	obj := l.unpack(ctx, v)
+	if obj == nil && diagnostics.errCount > count {
+		return newPlaceholder(ctx.Path.String(), diagnostics.lastErr)
+	}
+	return obj
+}
+
+func (l *Loader) unpack(ctx *errctx.Context, v interface{}) fyne.CanvasObject {
 	if v == nil {
 		return nil
 	}
 
 	switch w := v.(type) {
 	case map[string]interface{}:
+		if !l.included(ctx, w) {
+			return nil
+		}
+
 		typename, ok, err := maputil.GetString(w, KeyType)
 		if err != nil {
 			ctx.ErrorWithKey(err, KeyType)
@@ -184,19 +490,44 @@ func (l *Loader) Unpack(ctx *errctx.Context, v interface{}) fyne.CanvasObject {
 			return nil
 		}
 
-		cb, ok := l.elements[typename]
-		if !ok {
+		var obj fyne.CanvasObject
+		if cb, ok := l.elements[typename]; ok {
+			l.checkKeys(ctx, typename, w)
+			obj = l.construct(ctx, typename, cb, l.applyStyles(ctx, typename, w))
+		} else if c, ok := l.components[typename]; ok {
+			obj = l.unpackComponent(ctx, typename, c, w)
+		} else {
+			switch typename {
+			case ValueSlot:
+				ctx.ErrorWithKey(ErrSlotOutsideComponent, KeyType)
+				return nil
+			case ValueRepeat:
+				ctx.ErrorWithKey(ErrRepeatOutsideChildren, KeyType)
+				return nil
+			}
 			ctx.ErrorWithKey(UnknownElementType{TypeName: typename}, KeyType)
 			return nil
 		}
-		return cb(ctx, l, w)
+		l.registerID(ctx, w, obj)
+		return obj
 	case string:
 		cb, ok := l.elements[w]
 		if !ok {
+			if c, ok := l.components[w]; ok {
+				return l.unpackComponent(ctx, w, c, nil)
+			}
+			switch w {
+			case ValueSlot:
+				ctx.Error(ErrSlotOutsideComponent)
+				return nil
+			case ValueRepeat:
+				ctx.Error(ErrRepeatOutsideChildren)
+				return nil
+			}
 			ctx.Error(UnknownElementType{TypeName: w})
 			return nil
 		}
-		return cb(ctx, l, nil)
+		return l.construct(ctx, w, cb, l.applyStyles(ctx, w, nil))
 	}
 	ctx.Error(maputil.InvalidTypeError{
 		Actual:   maputil.TypeName(v),
@@ -204,3 +535,64 @@ func (l *Loader) Unpack(ctx *errctx.Context, v interface{}) fyne.CanvasObject {
 	})
 	return nil
 }
+
+// isSection returns true if the given top-level key names a section of the
+// definition file rather than a root element.
+func isSection(name string) bool {
+	switch name {
+	case KeyAnimations, KeyComponents, KeyDefaults, KeyDialogs, KeyMainMenus, KeyMenus,
+		KeyShortcuts, KeyStyles, KeyTheme, KeyVars, KeyWindows:
+		return true
+	}
+	return false
+}
+
+// decodeFile reads a file as either YAML or JSON based on its extension.
+func decodeFile(path string) (map[string]interface{}, positions, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	var decode func(io.Reader) (map[string]interface{}, positions, error)
+	switch ext {
+	case ".yaml", ".yml":
+		decode = decodeYAML
+	case ".json":
+		decode = decodeJSON
+	default:
+		return nil, nil, fmt.Errorf("%w %q", ErrUnknownFileExt, ext)
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer in.Close()
+	return decode(in)
+}
+
+// decodeYAML decodes YAML data along with the positions of its elements.
+func decodeYAML(in io.Reader) (map[string]interface{}, positions, error) {
+	var node yaml.Node
+	if err := yaml.NewDecoder(in).Decode(&node); err != nil {
+		return nil, nil, err
+	}
+	var generic map[string]interface{}
+	if err := node.Decode(&generic); err != nil {
+		return nil, nil, err
+	}
+	pos := positions{}
+	yamlPositions(pos, readerName(in), nil, &node)
+	return generic, pos, nil
+}
+
+// decodeJSON decodes JSON data along with the positions of its elements.
+func decodeJSON(in io.Reader) (map[string]interface{}, positions, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, nil, err
+	}
+	d := newJSONDecoder(data, readerName(in))
+	generic, err := d.decode()
+	if err != nil {
+		return nil, nil, err
+	}
+	return generic, d.positions, nil
+}
@@ -1,10 +1,15 @@
 package fyneloader_test
 
 import (
+	"os"
+	"os/exec"
+	"strings"
 	"testing"
 
+	"fyne.io/fyne/v2"
 	"github.com/stretchr/testify/require"
 	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
 )
 
 func TestReader(t *testing.T) {
@@ -25,4 +30,112 @@ func TestReader(t *testing.T) {
 			)
 		})
 	})
+	t.Run("RegisterMethods", func(t *testing.T) {
+		t.Parallel()
+		t.Run("Namespace", func(t *testing.T) {
+			t.Parallel()
+			l := fyneloader.New()
+			c := &controller{}
+			require.NoError(t, l.RegisterMethods("settings", c))
+			require.Equal(t, []string{"settings.Rename", "settings.Save"}, l.FuncNames())
+
+			fn, err := fyneloader.GetFn[func()](l, map[string]interface{}{"func": "settings.Save"}, "func")
+			require.NoError(t, err)
+			fn()
+			require.Equal(t, 1, c.saved)
+		})
+		t.Run("NoNamespace", func(t *testing.T) {
+			t.Parallel()
+			l := fyneloader.New()
+			require.NoError(t, l.RegisterMethods("", controller{}))
+			require.Equal(t, []string{"Rename"}, l.FuncNames())
+		})
+		t.Run("Collision", func(t *testing.T) {
+			t.Parallel()
+			l := fyneloader.New()
+			require.NoError(t, l.RegisterFunc("Save", func() {}))
+			require.NoError(t, l.RegisterFunc("Rename", func() {}))
+			err := l.RegisterMethods("", &controller{})
+			require.Equal(t, fyneloader.DuplicateFunctionError{Names: []string{"Rename", "Save"}}, err)
+			require.EqualError(t, err, `functions "Rename", "Save" are already registered`)
+			require.Equal(t, []string{"Rename", "Save"}, l.FuncNames())
+
+			require.NoError(t, l.RegisterMethods("main", &controller{}))
+			require.EqualError(
+				t, l.RegisterMethods("main", &controller{}),
+				`functions "main.Rename", "main.Save" are already registered`,
+			)
+		})
+		t.Run("NoMethods", func(t *testing.T) {
+			t.Parallel()
+			l := fyneloader.New()
+			require.Equal(t, fyneloader.ErrNoMethods, l.RegisterMethods("x", struct{}{}))
+			require.Equal(t, fyneloader.ErrNoMethods, l.RegisterMethods("x", nil))
+		})
+		t.Run("NilPointer", func(t *testing.T) {
+			t.Parallel()
+			l := fyneloader.New()
+			err := l.RegisterMethods("x", (*controller)(nil))
+			require.Equal(t, fyneloader.ErrNilReceiver, err)
+			require.Equal(t, "FL011", fyneloader.ErrorCode(err))
+			require.Empty(t, l.FuncNames())
+		})
+	})
+}
+
+type controller struct {
+	saved int
+}
+
+func (c *controller) Save() {
+	c.saved++
+}
+
+func (c controller) Rename(string) {}
+
+// noAppEnv is set in the environment of the subprocess started by TestNoApp.
+const noAppEnv = "FYNELOADER_TEST_NO_APP"
+
+// TestNoApp loads a definition with no current fyne app. Importing the fyne
+// test package installs an app for the whole test binary, so the test runs
+// itself again in a subprocess which removes that app before loading; this
+// leaves the app of the other tests untouched.
+func TestNoApp(t *testing.T) {
+	t.Parallel()
+	if os.Getenv(noAppEnv) == "" {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestNoApp$", "-test.count=1")
+		cmd.Env = append(os.Environ(), noAppEnv+"=1")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return
+	}
+
+	const def = `
+vars:
+  accent: "#00ff00"
+styles:
+  rectangle: {min-size: {width: 4, height: 4}}
+menus:
+  file:
+    label: File
+    items:
+    - {label: Quit, func: quit}
+shortcuts:
+- {shortcut: ctrl+q, func: quit}
+animations:
+  pulse: {target: light, property: opacity, to: 0.5, auto-start: true}
+root:
+  type: vbox
+  children:
+  - {type: rectangle, id: light, color: "${accent}"}
+  - spacer
+`
+	fyne.SetCurrentApp(nil)
+	l := fyneloader.New()
+	require.NoError(t, l.RegisterFunc("quit", func() {}))
+	ctx := errctx.New()
+	doc, err := l.LoadYAML(ctx, strings.NewReader(def))
+	require.NoError(t, err)
+	require.Len(t, doc.Roots, 1)
+	require.NotPanics(t, doc.StartAnimations)
 }
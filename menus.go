@@ -0,0 +1,147 @@
+package fyneloader
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// readMenus reads the menus section of a definition file.
+func (l *Loader) readMenus(ctx *errctx.Context, raw interface{}) map[string]*fyne.Menu {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	menus := make(map[string]*fyne.Menu, len(data))
+	for name, v := range data {
+		ctx.Path.Add(mpath.Key(name))
+		if menu := l.GetMenu(ctx, v); menu != nil {
+			menus[name] = menu
+		}
+		ctx.Path.Pop()
+	}
+	return menus
+}
+
+// readMainMenus reads the main-menus section of a definition file.
+//
+// Each main menu is a list of menus, given either by the name of a menu in the
+// menus section or as a menu definition.
+func (l *Loader) readMainMenus(
+	ctx *errctx.Context, raw interface{}, menus map[string]*fyne.Menu,
+) map[string]*fyne.MainMenu {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	mainMenus := make(map[string]*fyne.MainMenu, len(data))
+	for name := range data {
+		entries := unpack.RequireArray(ctx, data, name)
+		if entries == nil {
+			continue
+		}
+		ctx.Path.Add(mpath.Key(name))
+		items := make([]*fyne.Menu, 0, len(entries))
+		for i, v := range entries {
+			if ref, ok := v.(string); ok {
+				menu, ok := menus[ref]
+				if !ok {
+					ctx.ErrorWithIndex(UndefinedMenuError{Name: ref}, i)
+					continue
+				}
+				items = append(items, menu)
+				continue
+			}
+			ctx.Path.Add(mpath.Index(i))
+			if menu := l.GetMenu(ctx, v); menu != nil {
+				items = append(items, menu)
+			}
+			ctx.Path.Pop()
+		}
+		ctx.Path.Pop()
+		mainMenus[name] = fyne.NewMainMenu(items...)
+	}
+	return mainMenus
+}
+
+// GetMenu interprets a value as a menu definition with a label and a list of
+// items.
+func (l *Loader) GetMenu(ctx *errctx.Context, v interface{}) *fyne.Menu {
+	data, err := maputil.AsObject(v)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+	label := unpack.RequireString(ctx, data, KeyLabel)
+	return fyne.NewMenu(label, l.GetMenuItems(ctx, data)...)
+}
+
+// GetMenuItems fetches the value of the items key and interprets it as a list
+// of menu items.
+//
+// Each item is either the string `separator` or an object with a label and
+// optionally a func, shortcut, checked and disabled state, and a list of items
+// for a child menu.
+func (l *Loader) GetMenuItems(ctx *errctx.Context, data map[string]interface{}) []*fyne.MenuItem {
+	raw := unpack.RequireArray(ctx, data, KeyItems)
+	ctx.Path.Add(mpath.Key(KeyItems))
+	defer ctx.Path.Pop()
+
+	items := make([]*fyne.MenuItem, 0, len(raw))
+	for i, v := range raw {
+		ctx.Path.Add(mpath.Index(i))
+		if item := l.getMenuItem(ctx, v); item != nil {
+			items = append(items, item)
+		}
+		ctx.Path.Pop()
+	}
+	return items
+}
+
+func (l *Loader) getMenuItem(ctx *errctx.Context, v interface{}) *fyne.MenuItem {
+	if s, ok := v.(string); ok {
+		if s != ValueSeparator {
+			ctx.Error(maputil.EnumStringError{Value: s, Enum: []string{ValueSeparator}})
+			return nil
+		}
+		return fyne.NewMenuItemSeparator()
+	}
+
+	data, err := maputil.AsObject(v)
+	if err != nil {
+		ctx.Error(maputil.InvalidTypeError{
+			Actual:   maputil.TypeName(v),
+			Expected: []string{maputil.TypeObject, maputil.TypeString},
+		})
+		return nil
+	}
+	if !l.included(ctx, data) {
+		return nil
+	}
+
+	item := &fyne.MenuItem{
+		Label:    unpack.RequireString(ctx, data, KeyLabel),
+		Action:   l.getFnVoidToVoid(ctx, data, KeyFunc),
+		Checked:  unpack.OptionalBoolean(ctx, data, KeyChecked, false),
+		Disabled: unpack.OptionalBoolean(ctx, data, KeyDisabled, false),
+		IsQuit:   unpack.OptionalBoolean(ctx, data, KeyQuit, false),
+	}
+	if s := unpack.OptionalString(ctx, data, KeyShortcut, ""); s != "" {
+		shortcut, err := ParseShortcut(s)
+		if err != nil {
+			ctx.ErrorWithKey(err, KeyShortcut)
+		} else {
+			item.Shortcut = shortcut
+		}
+	}
+	if _, ok := data[KeyItems]; ok {
+		item.ChildMenu = fyne.NewMenu("", l.GetMenuItems(ctx, data)...)
+	}
+	return item
+}
@@ -0,0 +1,93 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestMenus(t *testing.T) {
+	t.Parallel()
+	t.Run("Load", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+menus:
+  file:
+    label: File
+    items:
+    - {label: Save, func: save, shortcut: ctrl+s}
+    - separator
+    - label: Recent
+      items:
+      - {label: a.yaml, disabled: true}
+    - {label: Quit, quit: true}
+  view:
+    label: View
+    items:
+    - {label: Wrap, checked: true, func: [{show: content}]}
+main-menus:
+  main:
+  - file
+  - view
+  - label: Help
+    items:
+    - {label: About}
+content: {type: label, id: content}
+`
+		saved := false
+		l := fyneloader.New()
+		require.NoError(t, l.RegisterFunc("save", func() { saved = true }))
+		ctx := errctx.New()
+		doc, err := l.LoadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Len(t, doc.Roots, 1)
+		require.Len(t, doc.Menus, 2)
+
+		file := doc.Menus["file"]
+		require.Equal(t, "File", file.Label)
+		require.Len(t, file.Items, 4)
+		file.Items[0].Action()
+		require.True(t, saved)
+		require.Equal(
+			t,
+			&desktop.CustomShortcut{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierControl},
+			file.Items[0].Shortcut,
+		)
+		require.True(t, file.Items[1].IsSeparator)
+		require.True(t, file.Items[2].ChildMenu.Items[0].Disabled)
+		require.True(t, file.Items[3].IsQuit)
+		require.True(t, doc.Menus["view"].Items[0].Checked)
+
+		main := doc.MainMenus["main"]
+		require.Len(t, main.Items, 3)
+		require.Same(t, file, main.Items[0])
+		require.Equal(t, "Help", main.Items[2].Label)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+menus:
+  file:
+    label: File
+    items:
+    - {label: Open, shortcut: ctrl+nope}
+    - spacer
+main-menus:
+  main: [file, edit]
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, 3, ctx.ErrorCount(), sb.String())
+		require.Contains(t, sb.String(), "menus.file.items[0].shortcut: 6:21: invalid shortcut \"ctrl+nope\": unknown key \"nope\"")
+		require.Contains(t, sb.String(), "menus.file.items[1]: ")
+		require.Contains(t, sb.String(), "main-menus.main[1]: 9:16: no menu \"edit\" defined")
+	})
+}
@@ -0,0 +1,51 @@
+package fyneloader
+
+import (
+	"image/color"
+	"runtime/debug"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/tvarney/maputil/errctx"
+)
+
+// construct calls the constructor of an element. A panic in the constructor is
+// reported as a PanicError and the element is replaced with a placeholder, so
+// that the rest of the document still loads.
+func (l *Loader) construct(
+	ctx *errctx.Context, typename string, cb CreateElementFn, data map[string]interface{},
+) (obj fyne.CanvasObject) {
+	depth := len(ctx.Path.Elements)
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		ctx.Path.Elements = ctx.Path.Elements[:depth]
+		err := PanicError{
+			Path:     ctx.Path.String(),
+			TypeName: typename,
+			Value:    r,
+			Stack:    debug.Stack(),
+		}
+		ctx.Error(err)
+		obj = newPlaceholder(err.Path, err)
+	}()
+	return cb(ctx, l, data)
+}
+
+// newPlaceholder returns the widget shown in place of an element which failed
+// to load: the error and the path of the element inside a red border.
+func newPlaceholder(path string, err error) fyne.CanvasObject {
+	border := canvas.NewRectangle(color.Transparent)
+	border.StrokeColor = theme.ErrorColor()
+	border.StrokeWidth = 2
+
+	message := widget.NewLabel(err.Error())
+	message.Wrapping = fyne.TextWrapWord
+	location := widget.NewLabelWithStyle(path, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	return container.NewMax(border, container.NewVBox(message, location))
+}
@@ -0,0 +1,141 @@
+package fyneloader_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+)
+
+func TestPanics(t *testing.T) {
+	t.Parallel()
+
+	const def = `
+root:
+  type: vbox
+  children:
+  - type: label
+    text: before
+  - type: broken
+    reason: boom
+  - broken
+  - type: widget
+`
+
+	errBroken := errors.New("broken element")
+	newLoader := func() *fyneloader.Loader {
+		l := fyneloader.New()
+		l.RegisterElement("broken", func(ctx *errctx.Context, l *fyneloader.Loader, data map[string]interface{}) fyne.CanvasObject {
+			ctx.Path.Add(mpath.Key("reason"))
+			if data == nil {
+				panic(errBroken)
+			}
+			panic(data["reason"])
+		})
+		return l
+	}
+
+	t.Run("Recover", func(t *testing.T) {
+		t.Parallel()
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := newLoader().ReadYAML(ctx, strings.NewReader(def))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"root.children[1]: 7:5: element \"broken\" panicked: boom\n"+
+				"root.children[2]: 9:5: element \"broken\" panicked: broken element\n"+
+				"root.children[3].type: 10:5: unknown element \"widget\"\n",
+			sb.String(),
+		)
+
+		box := roots["root"].(*fyne.Container)
+		require.Len(t, box.Objects, 3)
+		require.Equal(t, "before", box.Objects[0].(*widget.Label).Text)
+		for _, obj := range box.Objects[1:] {
+			placeholder := obj.(*fyne.Container)
+			require.IsType(t, container.NewVBox(), placeholder.Objects[1])
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+		ctx := errctx.New()
+		_, err := newLoader().Unmarshal(ctx, map[string]interface{}{"root": "broken"})
+		require.IsType(t, fyneloader.LoadError{}, err)
+
+		var perr fyneloader.PanicError
+		require.True(t, errors.As(ctx.LastError(), &perr))
+		require.Equal(t, "root", perr.Path)
+		require.Equal(t, "broken", perr.TypeName)
+		require.Contains(t, string(perr.Stack), "placeholder_test.go")
+		require.ErrorIs(t, perr, errBroken)
+		require.Equal(t, "FL128", fyneloader.ErrorCode(perr))
+	})
+	t.Run("DevMode", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+root:
+  type: vbox
+  children:
+  - type: label
+    text: before
+  - type: widget
+  - type: label
+    if: false
+`
+		for _, dev := range []bool{false, true} {
+			l := fyneloader.New()
+			l.DevMode = dev
+			roots, err := l.ReadYAML(errctx.New(), strings.NewReader(def))
+			require.IsType(t, fyneloader.LoadError{}, err)
+
+			box := roots["root"].(*fyne.Container)
+			if !dev {
+				require.Len(t, box.Objects, 1)
+				continue
+			}
+			require.Len(t, box.Objects, 2)
+			labels := box.Objects[1].(*fyne.Container).Objects[1].(*fyne.Container).Objects
+			require.Equal(t, "unknown element \"widget\"", labels[0].(*widget.Label).Text)
+			require.Equal(t, "root.children[1]", labels[1].(*widget.Label).Text)
+		}
+	})
+	t.Run("DevModeWarnings", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+root:
+  type: vbox
+  children:
+  - type: empty
+  - type: empty
+    fail: true
+`
+		errEmpty := errors.New("empty element")
+		for _, min := range []fyneloader.Severity{fyneloader.SeverityInfo, fyneloader.SeverityError} {
+			l := fyneloader.New()
+			l.DevMode = true
+			l.MinSeverity = min
+			l.RegisterElement("empty", func(ctx *errctx.Context, l *fyneloader.Loader, data map[string]interface{}) fyne.CanvasObject {
+				ctx.Error(fyneloader.Warning{Err: errEmpty})
+				if data["fail"] == true {
+					ctx.Error(errEmpty)
+				}
+				return nil
+			})
+			roots, err := l.ReadYAML(errctx.New(), strings.NewReader(def))
+			require.IsType(t, fyneloader.LoadError{}, err)
+
+			box := roots["root"].(*fyne.Container)
+			require.Len(t, box.Objects, 1)
+			labels := box.Objects[0].(*fyne.Container).Objects[1].(*fyne.Container).Objects
+			require.Equal(t, "root.children[1]", labels[1].(*widget.Label).Text)
+		}
+	})
+}
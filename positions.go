@@ -0,0 +1,269 @@
+package fyneloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a location within a definition file.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String returns the position in the `file:line:column` form understood by
+// most editors; the file is omitted if it is not known.
+func (p Position) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// positions maps the paths of a decoded definition to where they were
+// defined.
+type positions map[string]Position
+
+// lookup returns the position of the given path, or of its nearest ancestor
+// if the path itself was not part of the decoded definition; this is the case
+// for errors reported from within components and custom elements.
+func (p positions) lookup(elems []mpath.Element) (Position, bool) {
+	for n := len(elems); n >= 0; n-- {
+		if pos, ok := p[pathKey(elems[:n])]; ok {
+			return pos, true
+		}
+	}
+	return Position{}, false
+}
+
+// set records the position of a path unless it is already known.
+func (p positions) set(elems []mpath.Element, pos Position) {
+	key := pathKey(elems)
+	if _, ok := p[key]; !ok {
+		p[key] = pos
+	}
+}
+
+// install wraps the handler of the context so that errors are reported with
+// their position, returning a function which restores the original handler.
+func (p positions) install(ctx *errctx.Context) func() {
+	handler := ctx.Handler
+	if handler == nil || len(p) == 0 {
+		return func() {}
+	}
+	ctx.Handler = &positionHandler{next: handler, positions: p}
+	return func() { ctx.Handler = handler }
+}
+
+func pathKey(elems []mpath.Element) string {
+	sb := strings.Builder{}
+	for _, e := range elems {
+		switch v := e.(type) {
+		case mpath.Key:
+			sb.WriteString("\x00k")
+			sb.WriteString(string(v))
+		case mpath.Index:
+			sb.WriteString("\x00i")
+			sb.WriteString(strconv.Itoa(int(v)))
+		default:
+			sb.WriteString("\x00")
+			sb.WriteString(e.String())
+		}
+	}
+	return sb.String()
+}
+
+// positionHandler is an errctx.ErrorHandler which wraps errors in a
+// PositionError before passing them on.
+type positionHandler struct {
+	next      errctx.ErrorHandler
+	positions positions
+}
+
+func (h *positionHandler) Add(p *mpath.Path, err error) {
+	if pos, ok := h.positions.lookup(p.Elements); ok {
+		err = PositionError{Position: pos, Err: err}
+	}
+	h.next.Add(p, err)
+}
+
+// readerName returns the file name of the reader, if it has one.
+func readerName(in io.Reader) string {
+	if f, ok := in.(interface{ Name() string }); ok {
+		return f.Name()
+	}
+	return ""
+}
+
+// yamlPositions records the positions of the keys and array entries of the
+// given node.
+func yamlPositions(pos positions, file string, path []mpath.Element, n *yaml.Node) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			yamlPositions(pos, file, path, c)
+		}
+	case yaml.AliasNode:
+		yamlPositions(pos, file, path, n.Alias)
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			p := append(path, mpath.Index(i))
+			pos.set(p, Position{File: file, Line: c.Line, Column: c.Column})
+			yamlPositions(pos, file, p, c)
+		}
+	case yaml.MappingNode:
+		// Merged keys are handled last as explicit keys take precedence.
+		var merged []*yaml.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if k.Tag == "!!merge" {
+				merged = append(merged, v)
+				continue
+			}
+			p := append(path, mpath.Key(k.Value))
+			pos.set(p, Position{File: file, Line: k.Line, Column: k.Column})
+			yamlPositions(pos, file, p, v)
+		}
+		for _, v := range merged {
+			if v.Kind == yaml.SequenceNode {
+				for _, c := range v.Content {
+					yamlPositions(pos, file, path, c)
+				}
+				continue
+			}
+			yamlPositions(pos, file, path, v)
+		}
+	}
+}
+
+// jsonDecoder decodes JSON into generic values while recording the positions
+// of keys and array entries.
+type jsonDecoder struct {
+	dec       *json.Decoder
+	data      []byte
+	lines     []int
+	file      string
+	positions positions
+}
+
+func newJSONDecoder(data []byte, file string) *jsonDecoder {
+	lines := []int{0}
+	for i, c := range data {
+		if c == '\n' {
+			lines = append(lines, i+1)
+		}
+	}
+	return &jsonDecoder{
+		dec:       json.NewDecoder(bytes.NewReader(data)),
+		data:      data,
+		lines:     lines,
+		file:      file,
+		positions: positions{},
+	}
+}
+
+// token returns the next token along with the position at which it starts.
+func (d *jsonDecoder) token() (json.Token, Position, error) {
+	off := int(d.dec.InputOffset())
+	for off < len(d.data) && strings.IndexByte(" \t\r\n,:", d.data[off]) >= 0 {
+		off++
+	}
+	tok, err := d.dec.Token()
+	line := sort.Search(len(d.lines), func(i int) bool { return d.lines[i] > off })
+	return tok, Position{File: d.file, Line: line, Column: off - d.lines[line-1] + 1}, err
+}
+
+func (d *jsonDecoder) decode() (map[string]interface{}, error) {
+	tok, _, err := d.token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	v, err := d.value(nil, tok)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, &json.UnmarshalTypeError{
+			Value:  jsonTypeName(v),
+			Type:   reflect.TypeOf(m),
+			Offset: d.dec.InputOffset(),
+		}
+	}
+	return m, nil
+}
+
+func (d *jsonDecoder) value(path []mpath.Element, tok json.Token) (interface{}, error) {
+	switch tok {
+	case json.Delim('{'):
+		m := map[string]interface{}{}
+		for d.dec.More() {
+			tok, pos, err := d.token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := tok.(string)
+			p := append(path, mpath.Key(key))
+			d.positions.set(p, pos)
+			if m[key], err = d.next(p); err != nil {
+				return nil, err
+			}
+		}
+		_, err := d.dec.Token()
+		return m, err
+	case json.Delim('['):
+		a := []interface{}{}
+		for i := 0; d.dec.More(); i++ {
+			tok, pos, err := d.token()
+			if err != nil {
+				return nil, err
+			}
+			p := append(path, mpath.Index(i))
+			d.positions.set(p, pos)
+			v, err := d.value(p, tok)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		_, err := d.dec.Token()
+		return a, err
+	}
+	return tok, nil
+}
+
+func (d *jsonDecoder) next(path []mpath.Element) (interface{}, error) {
+	tok, _, err := d.token()
+	if err != nil {
+		return nil, err
+	}
+	return d.value(path, tok)
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return "null"
+}
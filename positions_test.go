@@ -0,0 +1,104 @@
+package fyneloader_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+)
+
+// errorList is an errctx.ErrorHandler which records the errors it is given.
+type errorList struct {
+	errs []error
+}
+
+func (h *errorList) Add(p *mpath.Path, err error) {
+	h.errs = append(h.errs, err)
+}
+
+func TestPositions(t *testing.T) {
+	t.Parallel()
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+		const def = `root:
+  type: vbox
+  children:
+  - type: label
+    text: [1]
+  - {type: widget}
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(def))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"root.children[0].text: 5:5: invalid type array; expected string\n"+
+				"root.children[1].type: 6:6: unknown element \"widget\"\n",
+			sb.String(),
+		)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "def.json")
+		def := "{\n  \"root\": {\n    \"type\": \"hbox\",\n    \"children\": [\"label\", \"widget\"]\n  }\n}\n"
+		require.NoError(t, os.WriteFile(path, []byte(def), 0o600))
+
+		h := &errorList{}
+		ctx := errctx.New(h)
+		_, err := fyneloader.New().LoadFile(ctx, path)
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Len(t, h.errs, 1)
+
+		var perr fyneloader.PositionError
+		require.True(t, errors.As(h.errs[0], &perr))
+		require.Equal(t, fyneloader.Position{File: path, Line: 4, Column: 27}, perr.Position)
+		require.Equal(t, fyneloader.UnknownElementType{TypeName: "widget"}, perr.Err)
+		require.Equal(t, fyneloader.UnknownElementType{TypeName: "widget"}, ctx.LastError())
+	})
+	t.Run("CustomElement", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+root:
+  type: vbox
+  children:
+  - label
+  - type: custom
+    options:
+      size: 3
+`
+		l := fyneloader.New()
+		l.RegisterElement("custom", func(ctx *errctx.Context, l *fyneloader.Loader, data map[string]interface{}) fyne.CanvasObject {
+			ctx.Path.Add(mpath.Key("options"))
+			ctx.ErrorWithKey(errors.New("bad size"), "size")
+			ctx.ErrorWithKey(errors.New("missing color"), "color")
+			ctx.Path.Pop()
+			return nil
+		})
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := l.ReadYAML(ctx, strings.NewReader(def))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"root.children[1].options.size: 8:7: bad size\n"+
+				"root.children[1].options.color: 7:5: missing color\n",
+			sb.String(),
+		)
+	})
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().Unmarshal(ctx, map[string]interface{}{"root": "widget"})
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, "root: unknown element \"widget\"\n", sb.String())
+	})
+}
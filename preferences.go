@@ -0,0 +1,107 @@
+package fyneloader
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// preferences returns the preferences used by elements with a preference key.
+func (l *Loader) preferences() fyne.Preferences {
+	if l.Preferences != nil {
+		return l.Preferences
+	}
+	if app := fyne.CurrentApp(); app != nil {
+		return app.Preferences()
+	}
+	return nil
+}
+
+// BindPreference binds the value of a widget to the preference named by the
+// preference key of data, if it has one.
+//
+// The initial value of the widget is read from the preference, falling back to
+// the value of the default key and then to the current value of the widget.
+// Changes to the value are written back to the preference before the OnChanged
+// callback of the widget is called. Checks, entries, radio groups, selects and
+// sliders are supported. Slider values are clamped to the range of the slider,
+// and values which are not one of the options of a radio group or select are
+// ignored.
+func (l *Loader) BindPreference(ctx *errctx.Context, data map[string]interface{}, obj fyne.CanvasObject) {
+	key := unpack.OptionalString(ctx, data, KeyPreference, "")
+	if key == "" {
+		return
+	}
+	prefs := l.preferences()
+	if prefs == nil {
+		ctx.ErrorWithKey(ErrNoPreferences, KeyPreference)
+		return
+	}
+
+	switch w := obj.(type) {
+	case *widget.Check:
+		w.Checked = prefs.BoolWithFallback(key, unpack.OptionalBoolean(ctx, data, KeyDefault, w.Checked))
+		onChanged := w.OnChanged
+		w.OnChanged = func(b bool) {
+			prefs.SetBool(key, b)
+			if onChanged != nil {
+				onChanged(b)
+			}
+		}
+	case *widget.Slider:
+		value := prefs.FloatWithFallback(key, unpack.OptionalNumber(ctx, data, KeyDefault, w.Value))
+		w.Value = clamp(value, w.Min, w.Max)
+		onChanged := w.OnChanged
+		w.OnChanged = func(f float64) {
+			prefs.SetFloat(key, f)
+			if onChanged != nil {
+				onChanged(f)
+			}
+		}
+	case *widget.Entry:
+		w.Text = prefs.StringWithFallback(key, unpack.OptionalString(ctx, data, KeyDefault, w.Text))
+		w.OnChanged = bindStringPreference(prefs, key, w.OnChanged)
+	case *widget.RadioGroup:
+		selected := prefs.StringWithFallback(key, unpack.OptionalString(ctx, data, KeyDefault, w.Selected))
+		w.Selected = selectedOption(w.Options, w.Selected, selected)
+		w.OnChanged = bindStringPreference(prefs, key, w.OnChanged)
+	case *widget.Select:
+		selected := prefs.StringWithFallback(key, unpack.OptionalString(ctx, data, KeyDefault, w.Selected))
+		w.Selected = selectedOption(w.Options, w.Selected, selected)
+		w.OnChanged = bindStringPreference(prefs, key, w.OnChanged)
+	default:
+		ctx.ErrorWithKey(PreferenceTypeError{Target: obj}, KeyPreference)
+	}
+}
+
+// clamp returns v limited to the range [min, max].
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// selectedOption returns value if it is one of the options, or the current
+// selection otherwise.
+func selectedOption(options []string, current, value string) string {
+	for _, o := range options {
+		if o == value {
+			return value
+		}
+	}
+	return current
+}
+
+func bindStringPreference(prefs fyne.Preferences, key string, onChanged func(string)) func(string) {
+	return func(s string) {
+		prefs.SetString(key, s)
+		if onChanged != nil {
+			onChanged(s)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestPreferences(t *testing.T) {
+	t.Parallel()
+	const doc = `
+root:
+  type: vbox
+  children:
+  - {type: check, preference: test.prefs.check, func: checked}
+  - {type: slider, preference: test.prefs.slider, default: 25}
+  - {type: entry, preference: test.prefs.entry, text: initial}
+  - {type: select, preference: test.prefs.select, options: [a, b, c], default: b}
+  - {type: radio, preference: test.prefs.radio, options: [x, y]}
+`
+	prefs := fyne.CurrentApp().Preferences()
+	for _, key := range []string{"slider", "entry", "select", "radio"} {
+		prefs.RemoveValue("test.prefs." + key)
+	}
+	prefs.SetBool("test.prefs.check", true)
+
+	load := func(t *testing.T) []fyne.CanvasObject {
+		t.Helper()
+		l := fyneloader.New()
+		require.NoError(t, l.RegisterFunc("checked", func(bool) {}))
+		ctx := errctx.New()
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		return roots["root"].(*fyne.Container).Objects
+	}
+
+	objs := load(t)
+	require.True(t, objs[0].(*widget.Check).Checked)
+	require.Equal(t, 25.0, objs[1].(*widget.Slider).Value)
+	require.Equal(t, "initial", objs[2].(*widget.Entry).Text)
+	require.Equal(t, "b", objs[3].(*widget.Select).Selected)
+	require.Equal(t, "", objs[4].(*widget.RadioGroup).Selected)
+
+	objs[0].(*widget.Check).SetChecked(false)
+	objs[1].(*widget.Slider).SetValue(75)
+	objs[2].(*widget.Entry).SetText("changed")
+	objs[3].(*widget.Select).SetSelected("c")
+	objs[4].(*widget.RadioGroup).SetSelected("y")
+
+	objs = load(t)
+	require.False(t, objs[0].(*widget.Check).Checked)
+	require.Equal(t, 75.0, objs[1].(*widget.Slider).Value)
+	require.Equal(t, "changed", objs[2].(*widget.Entry).Text)
+	require.Equal(t, "c", objs[3].(*widget.Select).Selected)
+	require.Equal(t, "y", objs[4].(*widget.RadioGroup).Selected)
+	require.Equal(t, "c", prefs.String("test.prefs.select"))
+}
+
+func TestPreferenceValues(t *testing.T) {
+	t.Parallel()
+	const doc = `
+root:
+  type: vbox
+  children:
+  - {type: slider, preference: test.values.high, max: 10}
+  - {type: slider, preference: test.values.low, min: 5, max: 10}
+  - {type: select, preference: test.values.select, options: [a, b], selected: a}
+  - {type: radio, preference: test.values.radio, options: [x, y], default: z}
+`
+	prefs := fyne.CurrentApp().Preferences()
+	prefs.SetFloat("test.values.high", 50)
+	prefs.SetFloat("test.values.low", 1)
+	prefs.SetString("test.values.select", "removed")
+
+	ctx := errctx.New()
+	roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+	require.NoError(t, err)
+	require.Zero(t, ctx.ErrorCount())
+
+	objs := roots["root"].(*fyne.Container).Objects
+	require.Equal(t, 10.0, objs[0].(*widget.Slider).Value)
+	require.Equal(t, 5.0, objs[1].(*widget.Slider).Value)
+	require.Equal(t, "a", objs[2].(*widget.Select).Selected)
+	require.Equal(t, "", objs[3].(*widget.RadioGroup).Selected)
+}
+
+func TestInputElements(t *testing.T) {
+	t.Parallel()
+	const doc = `
+root:
+  type: vbox
+  children:
+  - {type: entry, placeholder: Name, func: changed, on-submit: submitted}
+  - {type: entry, password: true}
+  - {type: entry, multi-line: true, wrap: word}
+  - {type: select, options: [a, b], selected: a, placeholder: Pick one, func: changed}
+`
+	var changed, submitted string
+	l := fyneloader.New()
+	require.NoError(t, l.RegisterFunc("changed", func(s string) { changed = s }))
+	require.NoError(t, l.RegisterFunc("submitted", func(s string) { submitted = s }))
+	ctx := errctx.New()
+	roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+	require.NoError(t, err)
+	require.Zero(t, ctx.ErrorCount())
+
+	objs := roots["root"].(*fyne.Container).Objects
+	entry := objs[0].(*widget.Entry)
+	require.Equal(t, "Name", entry.PlaceHolder)
+	entry.SetText("abc")
+	require.Equal(t, "abc", changed)
+	entry.OnSubmitted("abc")
+	require.Equal(t, "abc", submitted)
+	require.True(t, objs[1].(*widget.Entry).Password)
+	require.True(t, objs[2].(*widget.Entry).MultiLine)
+	require.Equal(t, fyne.TextWrapWord, objs[2].(*widget.Entry).Wrapping)
+
+	sel := objs[3].(*widget.Select)
+	require.Equal(t, "a", sel.Selected)
+	require.Equal(t, "Pick one", sel.PlaceHolder)
+	sel.SetSelected("b")
+	require.Equal(t, "b", changed)
+}
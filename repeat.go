@@ -0,0 +1,125 @@
+package fyneloader
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// defaultItemName is the name used to reference the current item of a repeat
+// element if none is given.
+const defaultItemName = "item"
+
+// RegisterData registers a named data set available to repeat elements.
+//
+// If the items are nil and there already exists a data set with the given
+// name, the data set will be removed. This function will replace a data set
+// with no error if a name is repeated.
+func (l *Loader) RegisterData(name string, items []interface{}) {
+	if items == nil {
+		delete(l.data, name)
+		return
+	}
+	l.data[name] = items
+}
+
+// GetChildren fetches the value for the 'children' key and attempts to unpack
+// each entry as an element.
+//
+// Repeat elements within the array are expanded in place.
+func (l *Loader) GetChildren(ctx *errctx.Context, data map[string]interface{}) []fyne.CanvasObject {
+	raw := unpack.OptionalArray(ctx, data, KeyChildren, nil)
+	if len(raw) == 0 {
+		return nil
+	}
+
+	ctx.Path.Add(mpath.Key(KeyChildren))
+	children := make([]fyne.CanvasObject, 0, len(raw))
+	for i, c := range raw {
+		ctx.Path.Add(mpath.Index(i))
+		if m, ok := c.(map[string]interface{}); ok && isRepeat(m) {
+			if l.included(ctx, m) {
+				children = append(children, l.unpackRepeat(ctx, m)...)
+			}
+		} else if child := l.Unpack(ctx, c); child != nil {
+			children = append(children, child)
+		}
+		ctx.Path.Pop()
+	}
+	ctx.Path.Pop()
+	return children
+}
+
+// unpackRepeat creates one element from the template of a repeat element for
+// each of its items.
+func (l *Loader) unpackRepeat(ctx *errctx.Context, data map[string]interface{}) []fyne.CanvasObject {
+	items := l.getRepeatItems(ctx, data)
+	as := unpack.OptionalString(ctx, data, KeyAs, defaultItemName)
+	template, ok := data[KeyTemplate]
+	if !ok || template == nil {
+		ctx.Error(maputil.MissingRequiredValueError{Key: KeyTemplate})
+		return nil
+	}
+
+	ctx.Path.Add(mpath.Key(KeyTemplate))
+	children := make([]fyne.CanvasObject, 0, len(items))
+	for i, item := range items {
+		if child := l.unpackRepeatItem(ctx, template, as, item, i); child != nil {
+			children = append(children, child)
+		}
+	}
+	ctx.Path.Pop()
+	return children
+}
+
+// unpackRepeatItem creates the element for a single item of a repeat element,
+// with the item and its index in scope.
+func (l *Loader) unpackRepeatItem(
+	ctx *errctx.Context, template interface{}, as string, item interface{}, index int,
+) fyne.CanvasObject {
+	l.scopes = append(l.scopes, map[string]interface{}{
+		as:         item,
+		ValueIndex: index,
+	})
+	defer func() {
+		l.scopes = l.scopes[:len(l.scopes)-1]
+	}()
+
+	return l.Unpack(ctx, l.substitute(ctx, template, l.lookup))
+}
+
+func (l *Loader) getRepeatItems(ctx *errctx.Context, data map[string]interface{}) []interface{} {
+	name, nameok, err := maputil.GetString(data, KeyData)
+	if err != nil {
+		ctx.ErrorWithKey(err, KeyData)
+		return nil
+	}
+	items, itemsok, err := maputil.GetArray(data, KeyItems)
+	if err != nil {
+		ctx.ErrorWithKey(err, KeyItems)
+		return nil
+	}
+
+	switch {
+	case nameok && itemsok:
+		ctx.Error(ConflictingKeysError{Keys: []string{KeyData, KeyItems}})
+		return nil
+	case nameok:
+		items, ok := l.data[name]
+		if !ok {
+			ctx.ErrorWithKey(UndefinedDataError{Name: name}, KeyData)
+		}
+		return items
+	case itemsok:
+		return items
+	}
+	ctx.Error(maputil.MissingRequiredValueError{Key: KeyItems})
+	return nil
+}
+
+func isRepeat(data map[string]interface{}) bool {
+	typename, _ := data[KeyType].(string)
+	return typename == ValueRepeat
+}
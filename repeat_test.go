@@ -0,0 +1,100 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestRepeat(t *testing.T) {
+	t.Parallel()
+	t.Run("Items", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: vbox
+  children:
+  - type: label
+    text: header
+  - type: repeat
+    items: [one, two, three]
+    template:
+      type: label
+      text: "${index}: ${item}"
+`
+		ctx := errctx.New()
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		box := roots["root"].(*fyne.Container)
+		require.Len(t, box.Objects, 4)
+		require.Equal(t, "0: one", box.Objects[1].(*widget.Label).Text)
+		require.Equal(t, "2: three", box.Objects[3].(*widget.Label).Text)
+	})
+	t.Run("Data", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: vbox
+  children:
+  - type: repeat
+    data: groups
+    as: group
+    template:
+      type: hbox
+      children:
+      - type: repeat
+        items: "${group.members}"
+        template:
+          type: button
+          text: "${group.name}/${item}"
+`
+		l := fyneloader.New()
+		l.RegisterData("groups", []interface{}{
+			map[string]interface{}{"name": "a", "members": []interface{}{"x", "y"}},
+			map[string]interface{}{"name": "b", "members": []interface{}{"z"}},
+		})
+		ctx := errctx.New()
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		box := roots["root"].(*fyne.Container)
+		require.Len(t, box.Objects, 2)
+		first := box.Objects[0].(*fyne.Container)
+		require.Len(t, first.Objects, 2)
+		require.Equal(t, "a/y", first.Objects[1].(*widget.Button).Text)
+		second := box.Objects[1].(*fyne.Container)
+		require.Len(t, second.Objects, 1)
+		require.Equal(t, "b/z", second.Objects[0].(*widget.Button).Text)
+	})
+	t.Run("UndefinedData", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: vbox
+  children:
+  - type: repeat
+    data: rows
+    template: label
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, "root.children[0].data: 6:5: no data set \"rows\" defined\n", sb.String())
+	})
+	t.Run("OutsideChildren", func(t *testing.T) {
+		t.Parallel()
+		ctx := errctx.New()
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader("root: {type: repeat, items: [], template: label}"))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, fyneloader.ErrRepeatOutsideChildren, ctx.LastError())
+	})
+}
@@ -0,0 +1,369 @@
+package fyneloader
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// SchemaDraft is the JSON Schema dialect of the schema returned by Schema.
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// object is shorthand for the JSON Schema fragments built in this file.
+type object = map[string]interface{}
+
+// The keys of the entries of the sections of a definition file.
+var (
+	animationKeys = []KeyDescriptor{
+		{Name: KeyTarget, Type: TypeString, Required: true, Description: "The ID of the animated element."},
+		{Name: KeyProperty, Type: TypeString, Enum: animationProperties, Required: true, Description: "The animated property."},
+		{Name: KeyFrom, Type: TypeAny, Description: "The value at the start of the animation."},
+		{Name: KeyTo, Type: TypeAny, Required: true, Description: "The value at the end of the animation."},
+		{
+			Name: KeyDuration, Type: TypeAny, Description: "The duration, such as 500ms, or a number of seconds.",
+			Schema: object{"type": []string{"string", "number"}},
+		},
+		{
+			Name: KeyCurve, Type: TypeString, Default: ValueEaseInOut, Description: "The animation curve.",
+			Enum: []string{ValueEaseIn, ValueEaseInOut, ValueEaseOut, ValueLinear},
+		},
+		{
+			Name: KeyRepeat, Type: TypeAny, Description: "The number of repeats, or true to repeat forever.",
+			Schema: object{"type": []string{"boolean", "integer"}},
+		},
+		{Name: KeyAutoReverse, Type: TypeBoolean, Default: false, Description: "Whether the animation reverses when repeating."},
+		{Name: KeyAutoStart, Type: TypeBoolean, Default: false, Description: "Whether the animation starts once loaded."},
+	}
+	componentKeys = []KeyDescriptor{
+		{
+			Name: KeyParams, Type: TypeObject, Description: "The parameters of the component.",
+			Schema: object{
+				"type": "object",
+				"additionalProperties": object{
+					"type": []string{"object", "null"},
+					"properties": object{
+						KeyDefault:  object{"description": "The value used if the parameter is not given."},
+						KeyRequired: object{"type": "boolean", "default": false},
+					},
+					"additionalProperties": false,
+				},
+			},
+		},
+		{Name: KeyContent, Type: TypeElement, Required: true, Description: "The element the component expands to."},
+	}
+	dialogKeys = []KeyDescriptor{
+		{Name: KeyType, Type: TypeString, Enum: dialogTypes, Required: true, Description: "The kind of dialog."},
+		{Name: KeyTitle, Type: TypeString, Description: "The title of the dialog."},
+		{Name: KeyMessage, Type: TypeString, Description: "The message of information and confirm dialogs."},
+		{Name: KeyContent, Type: TypeElement, Description: "The content of custom dialogs."},
+		{
+			Name: KeyItems, Type: TypeObjectArray, Description: "The items of form dialogs.",
+			Keys: []KeyDescriptor{
+				{Name: KeyLabel, Type: TypeString},
+				{Name: KeyContent, Type: TypeElement, Required: true},
+				{Name: KeyHint, Type: TypeString},
+			},
+		},
+		{Name: KeyConfirm, Type: TypeString, Description: "The text of the confirm button."},
+		{Name: KeyDismiss, Type: TypeString, Description: "The text of the dismiss button."},
+		{Name: KeyOnConfirm, Type: TypeFunc, Description: "The function or actions run when confirmed."},
+		{Name: KeyOnDismiss, Type: TypeFunc, Description: "The function or actions run when dismissed."},
+	}
+	shortcutEntryKeys = []KeyDescriptor{
+		{Name: KeyShortcut, Type: TypeString, Required: true, Description: "The key combination, such as ctrl+s."},
+		{Name: KeyFunc, Type: TypeFunc, Required: true, Description: "The function or actions run by the shortcut."},
+	}
+	windowKeys = []KeyDescriptor{
+		{Name: KeyTitle, Type: TypeString, Description: "The title of the window."},
+		{
+			Name: KeySize, Type: TypeObject, Description: "The initial size of the window.",
+			Keys: []KeyDescriptor{
+				{Name: KeyWidth, Type: TypeNumber, Required: true},
+				{Name: KeyHeight, Type: TypeNumber, Required: true},
+			},
+		},
+		{Name: KeyFixedSize, Type: TypeBoolean, Default: false},
+		{Name: KeyPadded, Type: TypeBoolean, Default: true},
+		{Name: KeyFullScreen, Type: TypeBoolean, Default: false},
+		{Name: KeyMaster, Type: TypeBoolean, Default: false, Description: "Whether closing the window quits the app."},
+		{Name: KeyContent, Type: TypeElement, Required: true, Description: "The name of a root element, or an element."},
+		{Name: KeyMainMenu, Type: TypeString, Description: "The name of the main menu of the window."},
+		{Name: KeyShortcuts, Type: TypeObjectArray, Keys: shortcutEntryKeys, Description: "The shortcuts of the window."},
+	}
+)
+
+// Schema returns a JSON Schema describing definition files for the element
+// types, functions and actions registered with the loader.
+//
+// Elements of types with a descriptor are checked against the keys of the
+// descriptor, or its Schema fragment if it has one; elements of other types,
+// including components, accept any keys.
+func (l *Loader) Schema() map[string]interface{} {
+	types := l.ElementTypes()
+	defs := object{
+		"reference": object{
+			"description": "A reference to a variable.",
+			"type":        "string",
+			"pattern":     `^\$\{[^}]*\}$`,
+		},
+		"text": object{"anyOf": []interface{}{
+			object{"type": "string"},
+			object{
+				"description": "A translated message.",
+				"type":        "object",
+				"required":    []string{KeyTranslation},
+				"properties": object{
+					KeyTranslation: object{"type": "string"},
+					KeyCount:       object{},
+					KeyArgs:        object{"type": "object"},
+				},
+			},
+		}},
+		"color": object{"anyOf": []interface{}{
+			object{"type": "string", "pattern": "^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$"},
+			object{"$ref": "#/$defs/reference"},
+		}},
+		"func": object{"anyOf": []interface{}{
+			object{"type": "string", "examples": l.FuncNames()},
+			object{"type": "array", "items": object{"$ref": "#/$defs/action"}},
+		}},
+		"action": object{"anyOf": []interface{}{
+			object{"type": "string", "examples": l.FuncNames()},
+			object{
+				"type":          "object",
+				"minProperties": 1,
+				"maxProperties": 1,
+				"propertyNames": object{"enum": l.ActionNames()},
+			},
+		}},
+		"menu": object{
+			"type": "object",
+			"properties": object{
+				KeyLabel: object{"$ref": "#/$defs/text"},
+				KeyItems: object{"type": "array", "items": object{"$ref": "#/$defs/menu-item"}},
+			},
+			"additionalProperties": false,
+		},
+		"menu-item": object{"anyOf": []interface{}{
+			object{"const": ValueSeparator},
+			object{
+				"type": "object",
+				"properties": object{
+					KeyLabel:    object{"$ref": "#/$defs/text"},
+					KeyFunc:     object{"$ref": "#/$defs/func"},
+					KeyChecked:  object{"type": "boolean"},
+					KeyDisabled: object{"type": "boolean"},
+					KeyQuit:     object{"type": "boolean"},
+					KeyShortcut: object{"type": "string"},
+					KeyItems:    object{"type": "array", "items": object{"$ref": "#/$defs/menu-item"}},
+					KeyIf:       object{"type": []string{"string", "boolean"}},
+				},
+				"additionalProperties": false,
+			},
+		}},
+	}
+
+	typeSchema := object{"type": "string", "examples": types}
+	conditions := make([]interface{}, 0, len(types)+1)
+	for _, name := range types {
+		desc, ok := l.descriptors[name]
+		if !ok {
+			continue
+		}
+		defs["element-"+name] = elementSchema(name, desc)
+		conditions = append(conditions, object{
+			"if":   object{"properties": object{KeyType: object{"const": name}}},
+			"then": object{"$ref": "#/$defs/element-" + name},
+		})
+	}
+	defs["element-"+ValueRepeat] = elementSchema(ValueRepeat, &ElementDescriptor{
+		Description: "Creates an element from the template for each item.",
+		Keys: []KeyDescriptor{
+			{Name: KeyTemplate, Type: TypeElement, Required: true},
+			{Name: KeyItems, Type: TypeAny, Schema: object{"type": "array"}},
+			{Name: KeyData, Type: TypeString, Description: "The name of a registered data set."},
+			{Name: KeyAs, Type: TypeString, Description: "The name of the current item."},
+		},
+	})
+	conditions = append(conditions, object{
+		"if":   object{"properties": object{KeyType: object{"const": ValueRepeat}}},
+		"then": object{"$ref": "#/$defs/element-" + ValueRepeat},
+	})
+	defs["element"] = object{"anyOf": []interface{}{
+		typeSchema,
+		object{
+			"type":       "object",
+			"required":   []string{KeyType},
+			"properties": object{KeyType: typeSchema},
+			"allOf":      conditions,
+		},
+	}}
+
+	colorNames := themeColorKeys()
+	sizeNames := themeSizeKeys()
+	return object{
+		"$schema":     SchemaDraft,
+		"title":       "fyne-loader definition",
+		"description": "A UI definition loaded by fyneloader.",
+		"type":        "object",
+		"properties": object{
+			KeyVars:       object{"type": "object", "description": "Variables referenced as ${name}."},
+			KeyComponents: mapOf(objectSchema(componentKeys)),
+			KeyStyles:     mapOf(object{"type": "object"}),
+			KeyDefaults: object{
+				"type":                 "object",
+				"propertyNames":        object{"enum": types},
+				"additionalProperties": object{"type": "object"},
+			},
+			KeyTheme: object{
+				"type": "object",
+				"properties": object{
+					KeyColors: object{
+						"type":          "object",
+						"propertyNames": object{"enum": colorNames},
+						"additionalProperties": object{"anyOf": []interface{}{
+							object{"$ref": "#/$defs/color"},
+							object{
+								"type": "object",
+								"properties": object{
+									ValueLight: object{"$ref": "#/$defs/color"},
+									ValueDark:  object{"$ref": "#/$defs/color"},
+								},
+								"required":             []string{ValueLight, ValueDark},
+								"additionalProperties": false,
+							},
+						}},
+					},
+					KeySizes: object{
+						"type":                 "object",
+						"propertyNames":        object{"enum": sizeNames},
+						"additionalProperties": object{"type": "number"},
+					},
+					KeyFonts: object{
+						"type":                 "object",
+						"propertyNames":        object{"enum": themeFontStyles},
+						"additionalProperties": object{"type": "string"},
+					},
+					KeyIcons: mapOf(object{"type": "string"}),
+				},
+				"additionalProperties": false,
+			},
+			KeyAnimations: mapOf(objectSchema(animationKeys)),
+			KeyMenus:      mapOf(object{"$ref": "#/$defs/menu"}),
+			KeyMainMenus: mapOf(object{
+				"type": "array",
+				"items": object{"anyOf": []interface{}{
+					object{"type": "string"},
+					object{"$ref": "#/$defs/menu"},
+				}},
+			}),
+			KeyShortcuts: object{"type": "array", "items": objectSchema(shortcutEntryKeys)},
+			KeyDialogs:   mapOf(objectSchema(dialogKeys)),
+			KeyWindows:   mapOf(objectSchema(windowKeys)),
+		},
+		"additionalProperties": object{"$ref": "#/$defs/element"},
+		"$defs":                defs,
+	}
+}
+
+// WriteSchema writes the schema returned by Schema as indented JSON.
+func (l *Loader) WriteSchema(out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l.Schema())
+}
+
+// elementSchema returns the schema of elements of the given type.
+func elementSchema(name string, desc *ElementDescriptor) map[string]interface{} {
+	if desc.Schema != nil {
+		return desc.Schema
+	}
+	keys := append(CommonKeys(), desc.Keys...)
+	for i := range keys {
+		if keys[i].Name == KeyType {
+			keys[i].Schema = object{"const": name}
+		}
+	}
+	s := objectSchema(keys)
+	if desc.Description != "" {
+		s["description"] = desc.Description
+	}
+	return s
+}
+
+// objectSchema returns the schema of an object with the given keys.
+func objectSchema(keys []KeyDescriptor) map[string]interface{} {
+	if len(keys) == 0 {
+		return object{"type": "object"}
+	}
+	properties := make(object, len(keys))
+	required := []string{}
+	for _, k := range keys {
+		properties[k.Name] = keySchema(k)
+		if k.Required {
+			required = append(required, k.Name)
+		}
+	}
+	s := object{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// keySchema returns the schema of the value of a key.
+//
+// Values other than strings may also be given as a reference to a variable.
+func keySchema(k KeyDescriptor) map[string]interface{} {
+	var s object
+	switch {
+	case k.Schema != nil:
+		s = make(object, len(k.Schema)+2)
+		for key, v := range k.Schema {
+			s[key] = v
+		}
+	case len(k.Enum) > 0:
+		s = orReference(object{"enum": k.Enum})
+	default:
+		switch k.Type {
+		case TypeBoolean, TypeInteger, TypeNumber:
+			s = orReference(object{"type": string(k.Type)})
+		case TypeColor:
+			s = object{"$ref": "#/$defs/color"}
+		case TypeString:
+			s = object{"$ref": "#/$defs/text"}
+		case TypeStringArray:
+			s = orReference(object{"type": "array", "items": object{"$ref": "#/$defs/text"}})
+		case TypeElement:
+			s = object{"$ref": "#/$defs/element"}
+		case TypeElements:
+			s = object{"type": "array", "items": object{"$ref": "#/$defs/element"}}
+		case TypeFunc:
+			s = object{"$ref": "#/$defs/func"}
+		case TypeObject:
+			s = objectSchema(k.Keys)
+		case TypeObjectArray:
+			s = object{"type": "array", "items": objectSchema(k.Keys)}
+		default:
+			s = object{}
+		}
+	}
+	if k.Description != "" {
+		s["description"] = k.Description
+	}
+	if k.Default != nil {
+		s["default"] = k.Default
+	}
+	return s
+}
+
+func orReference(s map[string]interface{}) map[string]interface{} {
+	return object{"anyOf": []interface{}{s, object{"$ref": "#/$defs/reference"}}}
+}
+
+func mapOf(s map[string]interface{}) map[string]interface{} {
+	return object{"type": "object", "additionalProperties": s}
+}
@@ -0,0 +1,116 @@
+package fyneloader_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+// refs returns every $ref in a decoded JSON value.
+func refs(v interface{}) []string {
+	var out []string
+	switch d := v.(type) {
+	case map[string]interface{}:
+		for k, e := range d {
+			if s, ok := e.(string); ok && k == "$ref" {
+				out = append(out, s)
+				continue
+			}
+			out = append(out, refs(e)...)
+		}
+	case []interface{}:
+		for _, e := range d {
+			out = append(out, refs(e)...)
+		}
+	}
+	return out
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	load := func(t *testing.T, l *fyneloader.Loader) map[string]interface{} {
+		t.Helper()
+		buf := &bytes.Buffer{}
+		require.NoError(t, l.WriteSchema(buf))
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &schema))
+		return schema
+	}
+
+	t.Run("Builtin", func(t *testing.T) {
+		t.Parallel()
+		schema := load(t, fyneloader.New())
+		require.Equal(t, fyneloader.SchemaDraft, schema["$schema"])
+		require.Equal(t, map[string]interface{}{"$ref": "#/$defs/element"}, schema["additionalProperties"])
+
+		defs := schema["$defs"].(map[string]interface{})
+		for _, ref := range refs(schema) {
+			require.True(t, strings.HasPrefix(ref, "#/$defs/"), ref)
+			require.Contains(t, defs, strings.TrimPrefix(ref, "#/$defs/"))
+		}
+
+		label := defs["element-label"].(map[string]interface{})
+		require.Equal(t, "A text label.", label["description"])
+		require.Equal(t, false, label["additionalProperties"])
+		props := label["properties"].(map[string]interface{})
+		require.Equal(t, "label", props[fyneloader.KeyType].(map[string]interface{})["const"])
+		require.Contains(t, props, fyneloader.KeyID)
+		require.Contains(t, props, fyneloader.KeyText)
+		wrap := props[fyneloader.KeyWrap].(map[string]interface{})
+		require.Equal(t, fyneloader.ValueDefault, wrap["default"])
+		require.Equal(
+			t, map[string]interface{}{"enum": []interface{}{"default", "off", "truncate", "break", "word"}},
+			wrap["anyOf"].([]interface{})[0],
+		)
+
+		vbox := defs["element-vbox"].(map[string]interface{})
+		children := vbox["properties"].(map[string]interface{})[fyneloader.KeyChildren]
+		require.Equal(t, map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"$ref": "#/$defs/element"},
+			"description": "The elements in the box.",
+		}, children)
+	})
+	t.Run("Custom", func(t *testing.T) {
+		t.Parallel()
+		create := func(*errctx.Context, *fyneloader.Loader, map[string]interface{}) fyne.CanvasObject {
+			return widget.NewLabel("")
+		}
+		l := fyneloader.New()
+		l.RegisterElement("gauge", create)
+		l.SetElementDescriptor("gauge", &fyneloader.ElementDescriptor{
+			Keys: []fyneloader.KeyDescriptor{
+				{Name: "value", Type: fyneloader.TypeNumber, Required: true},
+				{Name: "unit", Type: fyneloader.TypeString, Schema: map[string]interface{}{"pattern": "^[a-z]+$"}},
+			},
+		})
+		l.RegisterElement("chart", create)
+		l.SetElementDescriptor("chart", &fyneloader.ElementDescriptor{
+			Schema: map[string]interface{}{"type": "object", "required": []interface{}{"series"}},
+		})
+		l.RegisterElement("freeform", create)
+		require.NoError(t, l.RegisterFunc("save", func() {}))
+
+		schema := load(t, l)
+		defs := schema["$defs"].(map[string]interface{})
+		gauge := defs["element-gauge"].(map[string]interface{})
+		require.Equal(t, []interface{}{"type", "value"}, gauge["required"])
+		props := gauge["properties"].(map[string]interface{})
+		require.Equal(t, map[string]interface{}{"pattern": "^[a-z]+$"}, props["unit"])
+		require.Equal(t, map[string]interface{}{"type": "object", "required": []interface{}{"series"}}, defs["element-chart"])
+		require.NotContains(t, defs, "element-freeform")
+
+		element := defs["element"].(map[string]interface{})["anyOf"].([]interface{})[0].(map[string]interface{})
+		require.Contains(t, element["examples"], "freeform")
+		fn := defs["func"].(map[string]interface{})["anyOf"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, []interface{}{"save"}, fn["examples"])
+	})
+}
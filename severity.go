@@ -0,0 +1,107 @@
+package fyneloader
+
+import (
+	"errors"
+
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+)
+
+// Severity is the severity of a diagnostic reported while loading a
+// definition.
+type Severity int
+
+const (
+	// SeverityInfo is the severity of diagnostics wrapped in an Info.
+	SeverityInfo Severity = iota + 1
+
+	// SeverityWarning is the severity of diagnostics wrapped in a Warning.
+	SeverityWarning
+
+	// SeverityError is the severity of all other diagnostics.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// SeverityOf returns the severity of a diagnostic; errors which do not wrap an
+// Info or a Warning are errors.
+func SeverityOf(err error) Severity {
+	var info Info
+	if errors.As(err, &info) {
+		return SeverityInfo
+	}
+	var warning Warning
+	if errors.As(err, &warning) {
+		return SeverityWarning
+	}
+	return SeverityError
+}
+
+// diagnosticHandler is an errctx.ErrorHandler which drops diagnostics below the
+// minimum severity, adds positions to the rest, and collects those at or above
+// the threshold before passing them on. It also counts the diagnostics which
+// are errors, whatever the minimum severity, as the context counts them all.
+type diagnosticHandler struct {
+	next      errctx.ErrorHandler
+	positions positions
+	min       Severity
+	threshold Severity
+	errs      []error
+	errCount  int
+	lastErr   error
+}
+
+func (h *diagnosticHandler) Add(p *mpath.Path, err error) {
+	severity := SeverityOf(err)
+	if severity == SeverityError {
+		h.errCount++
+		h.lastErr = err
+	}
+	if severity < h.min {
+		return
+	}
+	if pos, ok := h.positions.lookup(p.Elements); ok {
+		err = PositionError{Position: pos, Err: err}
+	}
+	if severity >= h.threshold {
+		h.errs = append(h.errs, err)
+	}
+	if h.next != nil {
+		h.next.Add(p, err)
+	}
+}
+
+// installDiagnostics wraps the handler of the context for the duration of a
+// load, returning the wrapping handler and a function which restores the
+// original one.
+func (l *Loader) installDiagnostics(ctx *errctx.Context) (*diagnosticHandler, func()) {
+	outer := l.diagnostics
+	threshold := l.Threshold
+	if threshold == 0 {
+		threshold = SeverityError
+	}
+	handler := ctx.Handler
+	h := &diagnosticHandler{
+		next:      handler,
+		positions: l.positions,
+		min:       l.MinSeverity,
+		threshold: threshold,
+	}
+	ctx.Handler = h
+	l.diagnostics = h
+	return h, func() {
+		ctx.Handler = handler
+		l.diagnostics = outer
+	}
+}
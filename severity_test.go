@@ -0,0 +1,108 @@
+package fyneloader_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestSeverity(t *testing.T) {
+	t.Parallel()
+
+	const def = `
+root:
+  type: vbox
+  children:
+  - type: label
+    txt: Hello
+`
+
+	t.Run("SeverityOf", func(t *testing.T) {
+		t.Parallel()
+		err := errors.New("failed")
+		require.Equal(t, fyneloader.SeverityInfo, fyneloader.SeverityOf(fyneloader.Info{Err: err}))
+		require.Equal(t, fyneloader.SeverityWarning, fyneloader.SeverityOf(fyneloader.Warning{Err: err}))
+		require.Equal(t, fyneloader.SeverityError, fyneloader.SeverityOf(err))
+		require.Equal(t, fyneloader.SeverityWarning, fyneloader.SeverityOf(
+			fyneloader.PositionError{Err: fyneloader.Warning{Err: err}},
+		))
+		require.Equal(t, "warning", fyneloader.SeverityWarning.String())
+	})
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+		l := fyneloader.New()
+		l.Strict = fyneloader.StrictWarn
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := l.ReadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Contains(t, roots, "root")
+		require.Equal(
+			t, "root.children[0].txt: 6:5: warning: unknown key \"txt\", did you mean \"text\"?\n", sb.String(),
+		)
+	})
+	t.Run("Threshold", func(t *testing.T) {
+		t.Parallel()
+		l := fyneloader.New()
+		l.Strict = fyneloader.StrictWarn
+		l.Threshold = fyneloader.SeverityWarning
+		ctx := errctx.New()
+		roots, err := l.ReadYAML(ctx, strings.NewReader(def))
+		require.Contains(t, roots, "root")
+
+		var lerr fyneloader.LoadError
+		require.True(t, errors.As(err, &lerr))
+		require.Len(t, lerr.Errors, 1)
+		require.Equal(t, fyneloader.SeverityWarning, fyneloader.SeverityOf(lerr.Errors[0]))
+
+		var perr fyneloader.PositionError
+		require.True(t, errors.As(lerr.Errors[0], &perr))
+		require.Equal(t, fyneloader.Position{Line: 6, Column: 5}, perr.Position)
+		require.Equal(t, lerr.Errors[0].Error(), err.Error())
+	})
+	t.Run("MinSeverity", func(t *testing.T) {
+		t.Parallel()
+		l := fyneloader.New()
+		l.Strict = fyneloader.StrictWarn
+		l.MinSeverity = fyneloader.SeverityError
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := l.ReadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Empty(t, sb.String())
+	})
+	t.Run("Unmarshal", func(t *testing.T) {
+		t.Parallel()
+		ctx := errctx.New()
+		_, err := fyneloader.New().Unmarshal(ctx, map[string]interface{}{
+			"first":  "widget",
+			"second": map[string]interface{}{"type": "gadget"},
+		})
+		var lerr fyneloader.LoadError
+		require.True(t, errors.As(err, &lerr))
+		require.Len(t, lerr.Errors, 2)
+		require.Contains(t, err.Error(), "(and 1 more error)")
+	})
+	t.Run("LoadError", func(t *testing.T) {
+		t.Parallel()
+		errFailed := errors.New("failed")
+		warning := fyneloader.Warning{Err: errFailed}
+		for _, c := range []struct {
+			errs     []error
+			expected string
+		}{
+			{[]error{errFailed}, "failed"},
+			{[]error{errFailed, errFailed, errFailed}, "failed (and 2 more errors)"},
+			{[]error{warning, warning}, "warning: failed (and 1 more warning)"},
+			{[]error{errFailed, warning, warning}, "failed (and 2 more warnings)"},
+			{[]error{warning, errFailed, warning}, "warning: failed (and 2 more diagnostics)"},
+			{[]error{warning, fyneloader.Info{Err: errFailed}}, "warning: failed (and 1 more diagnostic)"},
+		} {
+			require.Equal(t, c.expected, fyneloader.LoadError{Errors: c.errs}.Error())
+		}
+	})
+}
@@ -0,0 +1,210 @@
+package fyneloader
+
+import (
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// shortcutModifiers maps the modifier names accepted in shortcuts to the fyne
+// key modifiers.
+var shortcutModifiers = map[string]fyne.KeyModifier{
+	"alt":     fyne.KeyModifierAlt,
+	"cmd":     fyne.KeyModifierSuper,
+	"control": fyne.KeyModifierControl,
+	"ctrl":    fyne.KeyModifierControl,
+	"meta":    fyne.KeyModifierSuper,
+	"option":  fyne.KeyModifierAlt,
+	"shift":   fyne.KeyModifierShift,
+	"super":   fyne.KeyModifierSuper,
+}
+
+// shortcutKeys maps the names of the non-alphanumeric keys accepted in
+// shortcuts to the fyne key names. Letters, digits and the function keys are
+// accepted by name.
+var shortcutKeys = map[string]fyne.KeyName{
+	"backspace": fyne.KeyBackspace,
+	"del":       fyne.KeyDelete,
+	"delete":    fyne.KeyDelete,
+	"down":      fyne.KeyDown,
+	"end":       fyne.KeyEnd,
+	"enter":     fyne.KeyReturn,
+	"esc":       fyne.KeyEscape,
+	"escape":    fyne.KeyEscape,
+	"home":      fyne.KeyHome,
+	"insert":    fyne.KeyInsert,
+	"kp-enter":  fyne.KeyEnter,
+	"left":      fyne.KeyLeft,
+	"page-down": fyne.KeyPageDown,
+	"page-up":   fyne.KeyPageUp,
+	"return":    fyne.KeyReturn,
+	"right":     fyne.KeyRight,
+	"space":     fyne.KeySpace,
+	"tab":       fyne.KeyTab,
+	"up":        fyne.KeyUp,
+
+	"apostrophe": fyne.KeyApostrophe,
+	"asterisk":   fyne.KeyAsterisk,
+	"backslash":  fyne.KeyBackslash,
+	"backtick":   fyne.KeyBackTick,
+	"comma":      fyne.KeyComma,
+	"equal":      fyne.KeyEqual,
+	"minus":      fyne.KeyMinus,
+	"period":     fyne.KeyPeriod,
+	"plus":       fyne.KeyPlus,
+	"semicolon":  fyne.KeySemicolon,
+	"slash":      fyne.KeySlash,
+	"[":          fyne.KeyLeftBracket,
+	"]":          fyne.KeyRightBracket,
+	"'":          fyne.KeyApostrophe,
+	"*":          fyne.KeyAsterisk,
+	"\\":         fyne.KeyBackslash,
+	"`":          fyne.KeyBackTick,
+	",":          fyne.KeyComma,
+	"=":          fyne.KeyEqual,
+	"-":          fyne.KeyMinus,
+	".":          fyne.KeyPeriod,
+	"+":          fyne.KeyPlus,
+	";":          fyne.KeySemicolon,
+	"/":          fyne.KeySlash,
+}
+
+// ParseShortcut parses a key combination such as `ctrl+s` or `alt+shift+f4`.
+//
+// A combination is any number of modifiers followed by a single key, separated
+// by `+`; the plus key itself is written as `+`, as in `ctrl++`. Names are not
+// case sensitive.
+func ParseShortcut(s string) (*desktop.CustomShortcut, error) {
+	combination := strings.ToLower(strings.TrimSpace(s))
+	var parts []string
+	switch {
+	case combination == "+":
+		parts = []string{"+"}
+	case strings.HasSuffix(combination, "++"):
+		// The plus key itself
+		parts = append(strings.Split(combination[:len(combination)-2], "+"), "+")
+	default:
+		parts = strings.Split(combination, "+")
+	}
+
+	shortcut := &desktop.CustomShortcut{}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if i < len(parts)-1 {
+			if part == "" {
+				return nil, ShortcutError{Shortcut: s, Msg: "missing modifier"}
+			}
+			mod, ok := shortcutModifiers[part]
+			if !ok {
+				return nil, ShortcutError{Shortcut: s, Msg: "unknown modifier " + strconv.Quote(part)}
+			}
+			shortcut.Modifier |= mod
+			continue
+		}
+
+		if part == "" {
+			return nil, ShortcutError{Shortcut: s, Msg: "missing key"}
+		}
+		key, ok := shortcutKey(part)
+		if !ok {
+			return nil, ShortcutError{Shortcut: s, Msg: "unknown key " + strconv.Quote(part)}
+		}
+		shortcut.KeyName = key
+	}
+	return shortcut, nil
+}
+
+func shortcutKey(name string) (fyne.KeyName, bool) {
+	if key, ok := shortcutKeys[name]; ok {
+		return key, true
+	}
+	if len(name) == 1 && (name[0] >= 'a' && name[0] <= 'z' || name[0] >= '0' && name[0] <= '9') {
+		return fyne.KeyName(strings.ToUpper(name)), true
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(name, "f")); err == nil && name[0] == 'f' && n >= 1 && n <= 12 {
+		return fyne.KeyName("F" + strconv.Itoa(n)), true
+	}
+	return "", false
+}
+
+// Shortcut is a keyboard shortcut declared in a shortcuts list, along with the
+// function it triggers.
+type Shortcut struct {
+	Shortcut *desktop.CustomShortcut
+	Action   func()
+}
+
+// AddShortcuts installs the given shortcuts on a canvas.
+func AddShortcuts(canvas fyne.Canvas, shortcuts []Shortcut) {
+	for _, s := range shortcuts {
+		action := s.Action
+		canvas.AddShortcut(s.Shortcut, func(fyne.Shortcut) {
+			if action != nil {
+				action()
+			}
+		})
+	}
+}
+
+// AddShortcuts installs the shortcuts declared at the root of the definition
+// on a canvas.
+func (d *Document) AddShortcuts(canvas fyne.Canvas) {
+	AddShortcuts(canvas, d.Shortcuts)
+}
+
+// readShortcuts reads a list of shortcuts, each an object with the key
+// combination as its shortcut and a func which is either the name of a
+// registered func() or a list of actions.
+func (l *Loader) readShortcuts(ctx *errctx.Context, raw interface{}) []Shortcut {
+	entries, err := maputil.AsArray(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	shortcuts := make([]Shortcut, 0, len(entries))
+	seen := map[string]struct{}{}
+	for i, v := range entries {
+		data, err := maputil.AsObject(v)
+		if err != nil {
+			ctx.ErrorWithIndex(err, i)
+			continue
+		}
+		ctx.Path.Add(mpath.Index(i))
+		s := l.readShortcut(ctx, data)
+		if s != nil {
+			name := s.Shortcut.ShortcutName()
+			if _, ok := seen[name]; ok {
+				ctx.ErrorWithKey(DuplicateShortcutError{Shortcut: data[KeyShortcut].(string)}, KeyShortcut)
+			} else {
+				seen[name] = struct{}{}
+				shortcuts = append(shortcuts, *s)
+			}
+		}
+		ctx.Path.Pop()
+	}
+	return shortcuts
+}
+
+func (l *Loader) readShortcut(ctx *errctx.Context, data map[string]interface{}) *Shortcut {
+	keys := unpack.RequireString(ctx, data, KeyShortcut)
+	action := l.getFnVoidToVoid(ctx, data, KeyFunc)
+	if _, ok := data[KeyFunc]; !ok {
+		ctx.ErrorWithKey(maputil.MissingRequiredValueError{Key: KeyFunc}, KeyFunc)
+	}
+	if keys == "" {
+		return nil
+	}
+	shortcut, err := ParseShortcut(keys)
+	if err != nil {
+		ctx.ErrorWithKey(err, KeyShortcut)
+		return nil
+	}
+	return &Shortcut{Shortcut: shortcut, Action: action}
+}
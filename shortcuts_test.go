@@ -0,0 +1,105 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestParseShortcut(t *testing.T) {
+	t.Parallel()
+	for s, expected := range map[string]desktop.CustomShortcut{
+		"ctrl+s":       {KeyName: fyne.KeyS, Modifier: fyne.KeyModifierControl},
+		"Alt+Shift+F4": {KeyName: fyne.KeyF4, Modifier: fyne.KeyModifierAlt | fyne.KeyModifierShift},
+		"super+1":      {KeyName: fyne.Key1, Modifier: fyne.KeyModifierSuper},
+		"ctrl++":       {KeyName: fyne.KeyPlus, Modifier: fyne.KeyModifierControl},
+		" Ctrl++ ":     {KeyName: fyne.KeyPlus, Modifier: fyne.KeyModifierControl},
+		"+":            {KeyName: fyne.KeyPlus},
+		"escape":       {KeyName: fyne.KeyEscape},
+	} {
+		shortcut, err := fyneloader.ParseShortcut(s)
+		require.NoError(t, err, s)
+		require.Equal(t, expected, *shortcut, s)
+	}
+	for _, s := range []string{"ctrl+", "ctrl+ ", "hyper+a", "ctrl+f13", "", "++", "+a", "ctrl++s"} {
+		_, err := fyneloader.ParseShortcut(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestShortcuts(t *testing.T) {
+	t.Parallel()
+	t.Run("Load", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+shortcuts:
+- {shortcut: ctrl+s, func: save}
+- {shortcut: alt+shift+f4, func: [{hide: status}]}
+windows:
+  main:
+    content: status
+    shortcuts:
+    - {shortcut: ctrl+s, func: save-window}
+status: {type: label, id: status}
+`
+		saved := ""
+		l := fyneloader.New()
+		require.NoError(t, l.RegisterFunc("save", func() { saved = "root" }))
+		require.NoError(t, l.RegisterFunc("save-window", func() { saved = "window" }))
+		ctx := errctx.New()
+		doc, err := l.LoadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Len(t, doc.Shortcuts, 2)
+		require.Len(t, doc.Windows["main"].Shortcuts, 1)
+
+		canvas := test.NewCanvas()
+		doc.AddShortcuts(canvas)
+		ctrlS := &desktop.CustomShortcut{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierControl}
+		canvas.(fyne.Shortcutable).TypedShortcut(ctrlS)
+		require.Equal(t, "root", saved)
+		canvas.(fyne.Shortcutable).TypedShortcut(&desktop.CustomShortcut{
+			KeyName: fyne.KeyF4, Modifier: fyne.KeyModifierAlt | fyne.KeyModifierShift,
+		})
+		require.False(t, doc.Roots["status"].Visible())
+
+		win := doc.CreateWindows(fyne.CurrentApp())["main"]
+		defer win.Close()
+		win.Canvas().(fyne.Shortcutable).TypedShortcut(ctrlS)
+		require.Equal(t, "window", saved)
+		doc.Roots["status"].Show()
+		win.Canvas().(fyne.Shortcutable).TypedShortcut(&desktop.CustomShortcut{
+			KeyName: fyne.KeyF4, Modifier: fyne.KeyModifierAlt | fyne.KeyModifierShift,
+		})
+		require.False(t, doc.Roots["status"].Visible())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+shortcuts:
+- {shortcut: ctrl+s, func: save}
+- {shortcut: Ctrl+S, func: save}
+- {shortcut: ctrl+foo, func: save}
+- {shortcut: ctrl+o}
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		l := fyneloader.New()
+		require.NoError(t, l.RegisterFunc("save", func() {}))
+		_, err := l.LoadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"shortcuts[1].shortcut: 4:4: duplicate shortcut \"Ctrl+S\"\n"+
+				"shortcuts[2].shortcut: 5:4: invalid shortcut \"ctrl+foo\": unknown key \"foo\"\n"+
+				"shortcuts[3].func: 6:3: missing required value \"func\"\n",
+			sb.String(),
+		)
+	})
+}
@@ -0,0 +1,138 @@
+package fyneloader
+
+import (
+	"sort"
+
+	"github.com/tvarney/maputil/errctx"
+)
+
+// StrictMode controls how keys which are not accepted by an element type are
+// reported.
+type StrictMode int
+
+const (
+	// StrictOff ignores unknown keys.
+	StrictOff StrictMode = iota
+
+	// StrictWarn reports unknown keys as warnings.
+	StrictWarn
+
+	// StrictError reports unknown keys as errors.
+	StrictError
+)
+
+// maxSuggestionDistance is the largest edit distance at which a valid key is
+// suggested for an unknown one.
+const maxSuggestionDistance = 2
+
+// checkKeys reports the keys of an element which are not accepted by its
+// type, if strict mode is enabled. Elements of types with no descriptor are not
+// checked.
+func (l *Loader) checkKeys(ctx *errctx.Context, typename string, data map[string]interface{}) {
+	if l.Strict == StrictOff {
+		return
+	}
+	desc, ok := l.descriptors[typename]
+	if !ok {
+		return
+	}
+
+	common := CommonKeys()
+	accepted := make(map[string]struct{}, len(desc.Keys)+len(common))
+	for _, k := range common {
+		accepted[k.Name] = struct{}{}
+	}
+	for _, k := range desc.Keys {
+		accepted[k.Name] = struct{}{}
+	}
+	l.reportUnknownKeys(ctx, data, accepted)
+}
+
+// checkStyleKeys reports the keys of a style which are not accepted by any
+// element type, if strict mode is enabled. As a style may be applied to an
+// element of any type, styles are not checked if any element type has no
+// descriptor.
+func (l *Loader) checkStyleKeys(ctx *errctx.Context, data map[string]interface{}) {
+	if l.Strict == StrictOff {
+		return
+	}
+	accepted := map[string]struct{}{}
+	for typename := range l.elements {
+		desc, ok := l.descriptors[typename]
+		if !ok {
+			return
+		}
+		for _, k := range desc.Keys {
+			accepted[k.Name] = struct{}{}
+		}
+	}
+	l.reportUnknownKeys(ctx, data, accepted)
+}
+
+// reportUnknownKeys reports each key of data which is not accepted, along with
+// the closest accepted key.
+func (l *Loader) reportUnknownKeys(ctx *errctx.Context, data map[string]interface{}, accepted map[string]struct{}) {
+	unknown := make([]string, 0, len(data))
+	for k := range data {
+		if _, ok := accepted[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		var err error = UnknownKeyError{Key: k, Suggestion: suggestKey(k, accepted)}
+		if l.Strict == StrictWarn {
+			err = Warning{Err: err}
+		}
+		ctx.ErrorWithKey(err, k)
+	}
+}
+
+// suggestKey returns the accepted key closest to the given key, or an empty
+// string if none is close enough.
+func suggestKey(key string, accepted map[string]struct{}) string {
+	candidates := make([]string, 0, len(accepted))
+	for k := range accepted {
+		candidates = append(candidates, k)
+	}
+	sort.Strings(candidates)
+
+	best, bestDist := "", maxSuggestionDistance+1
+	for _, k := range candidates {
+		if d := editDistance(key, k); d < bestDist && d < len(key) {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(v int, vs ...int) int {
+	for _, x := range vs {
+		if x < v {
+			v = x
+		}
+	}
+	return v
+}
@@ -0,0 +1,126 @@
+package fyneloader_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestStrict(t *testing.T) {
+	t.Parallel()
+	const def = `
+root:
+  type: vbox
+  children:
+  - {type: label, txt: Name, id: name}
+  - {type: button, text: Go, colour: red}
+  - {type: check, text: One, disabled: true}
+`
+	load := func(t *testing.T, mode fyneloader.StrictMode) (map[string]fyne.CanvasObject, *errctx.Context, string) {
+		t.Helper()
+		l := fyneloader.New()
+		l.Strict = mode
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := l.ReadYAML(ctx, strings.NewReader(def))
+		if ctx.ErrorCount() == 0 || mode == fyneloader.StrictWarn {
+			require.NoError(t, err)
+		} else {
+			require.IsType(t, fyneloader.LoadError{}, err)
+		}
+		return roots, ctx, sb.String()
+	}
+
+	t.Run("Off", func(t *testing.T) {
+		t.Parallel()
+		roots, ctx, _ := load(t, fyneloader.StrictOff)
+		require.Zero(t, ctx.ErrorCount())
+
+		check := roots["root"].(*fyne.Container).Objects[2].(*widget.Check)
+		require.Equal(t, "One", check.Text)
+		require.True(t, check.Disabled())
+	})
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+		_, ctx, out := load(t, fyneloader.StrictError)
+		require.Equal(t, 2, ctx.ErrorCount(), out)
+		require.Equal(
+			t,
+			"root.children[0].txt: 5:19: unknown key \"txt\", did you mean \"text\"?\n"+
+				"root.children[1].colour: 6:30: unknown key \"colour\"\n",
+			out,
+		)
+		require.Equal(t, fyneloader.UnknownKeyError{Key: "colour"}, ctx.LastError())
+	})
+	t.Run("Warn", func(t *testing.T) {
+		t.Parallel()
+		_, ctx, out := load(t, fyneloader.StrictWarn)
+		require.Equal(t, 2, ctx.ErrorCount(), out)
+		require.Contains(t, out, "warning: unknown key \"txt\", did you mean \"text\"?")
+
+		var warning fyneloader.Warning
+		require.True(t, errors.As(ctx.LastError(), &warning))
+		require.Equal(t, fyneloader.UnknownKeyError{Key: "colour"}, warning.Err)
+	})
+	t.Run("Styles", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+styles:
+  wide:
+    min-sise: {width: 100, height: 10}
+    align: center
+defaults:
+  label:
+    wrap: word
+    importance: high
+root: {type: label, class: wide}
+`
+		l := fyneloader.New()
+		l.Strict = fyneloader.StrictError
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := l.ReadYAML(ctx, strings.NewReader(def))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"styles.wide.min-sise: 4:5: unknown key \"min-sise\", did you mean \"min-size\"?\n"+
+				"defaults.label.importance: 9:5: unknown key \"importance\"\n",
+			sb.String(),
+		)
+	})
+	t.Run("CustomElement", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+root:
+  type: hbox
+  children:
+  - {type: gauge, vlaue: 3}
+  - {type: meter, vlaue: 3}
+`
+		create := func(*errctx.Context, *fyneloader.Loader, map[string]interface{}) fyne.CanvasObject {
+			return widget.NewLabel("")
+		}
+		l := fyneloader.New()
+		l.Strict = fyneloader.StrictError
+		l.RegisterElement("gauge", create)
+		l.RegisterElement("meter", create)
+		l.SetElementDescriptor("gauge", &fyneloader.ElementDescriptor{
+			Keys: []fyneloader.KeyDescriptor{
+				{Name: "value", Type: fyneloader.TypeNumber},
+				{Name: "max", Type: fyneloader.TypeNumber},
+			},
+		})
+
+		ctx := errctx.New()
+		_, err := l.ReadYAML(ctx, strings.NewReader(def))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, 1, ctx.ErrorCount())
+		require.Equal(t, fyneloader.UnknownKeyError{Key: "vlaue", Suggestion: "value"}, ctx.LastError())
+	})
+}
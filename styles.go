@@ -0,0 +1,160 @@
+package fyneloader
+
+import (
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+)
+
+// readStyles reads the styles section of a definition file.
+func (l *Loader) readStyles(ctx *errctx.Context, raw interface{}) {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	for name, v := range data {
+		ctx.Path.Add(mpath.Key(name))
+		if props := readStyle(ctx, name, v); props != nil {
+			l.checkStyleKeys(ctx, props)
+			l.styles[name] = props
+		}
+		ctx.Path.Pop()
+	}
+}
+
+// readDefaults reads the defaults section of a definition file, which gives
+// the properties applied to every element of a type.
+func (l *Loader) readDefaults(ctx *errctx.Context, raw interface{}) {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	for typename, v := range data {
+		if _, ok := l.elements[typename]; !ok {
+			ctx.ErrorWithKey(UnknownElementType{TypeName: typename}, typename)
+			continue
+		}
+		ctx.Path.Add(mpath.Key(typename))
+		if props := readStyle(ctx, typename, v); props != nil {
+			l.checkKeys(ctx, typename, props)
+			l.defaults[typename] = props
+		}
+		ctx.Path.Pop()
+	}
+}
+
+func readStyle(ctx *errctx.Context, name string, raw interface{}) map[string]interface{} {
+	props, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+	for k := range props {
+		switch k {
+		case KeyType, KeyID, KeyIf, KeyClass:
+			ctx.ErrorWithKey(StyleKeyError{Style: name, Key: k}, k)
+			return nil
+		}
+	}
+	return props
+}
+
+// applyStyles merges the defaults for the given element type and the styles
+// named by the class key of data into the properties of the element.
+//
+// Properties are merged shallowly, with the type defaults applied first, then
+// each class in the order listed, and finally the keys of the element itself;
+// a later value always replaces an earlier one. If there is nothing to merge,
+// data is returned unchanged.
+func (l *Loader) applyStyles(ctx *errctx.Context, typename string, data map[string]interface{}) map[string]interface{} {
+	defaults := l.defaults[typename]
+	classes := l.getClasses(ctx, data)
+	if defaults == nil && len(classes) == 0 {
+		return data
+	}
+
+	merged := make(map[string]interface{}, len(data)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for _, props := range classes {
+		for k, v := range props {
+			merged[k] = v
+		}
+	}
+	for k, v := range data {
+		merged[k] = v
+	}
+	return merged
+}
+
+// getClasses returns the properties of each style named by the class key of
+// data, which is either a single style name or a list of them.
+func (l *Loader) getClasses(ctx *errctx.Context, data map[string]interface{}) []map[string]interface{} {
+	raw, ok := data[KeyClass]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	switch d := raw.(type) {
+	case string:
+		props, ok := l.styles[d]
+		if !ok {
+			ctx.ErrorWithKey(UndefinedStyleError{Name: d}, KeyClass)
+			return nil
+		}
+		return []map[string]interface{}{props}
+	case []interface{}:
+		ctx.Path.Add(mpath.Key(KeyClass))
+		defer ctx.Path.Pop()
+		classes := make([]map[string]interface{}, 0, len(d))
+		for i, v := range d {
+			name, err := maputil.AsString(v)
+			if err != nil {
+				ctx.ErrorWithIndex(err, i)
+				continue
+			}
+			props, ok := l.styles[name]
+			if !ok {
+				ctx.ErrorWithIndex(UndefinedStyleError{Name: name}, i)
+				continue
+			}
+			classes = append(classes, props)
+		}
+		return classes
+	}
+	ctx.ErrorWithKey(maputil.InvalidTypeError{
+		Actual:   maputil.TypeName(raw),
+		Expected: []string{maputil.TypeString, maputil.TypeArray},
+	}, KeyClass)
+	return nil
+}
+
+// addClasses appends the classes given to a component to those of the root
+// element of its content.
+func addClasses(content interface{}, classes interface{}) interface{} {
+	if classes == nil {
+		return content
+	}
+	switch d := content.(type) {
+	case string:
+		return map[string]interface{}{KeyType: d, KeyClass: classes}
+	case map[string]interface{}:
+		existing, ok := d[KeyClass]
+		if !ok || existing == nil {
+			d[KeyClass] = classes
+			return d
+		}
+		d[KeyClass] = append(classList(existing), classList(classes)...)
+	}
+	return content
+}
+
+func classList(v interface{}) []interface{} {
+	if a, ok := v.([]interface{}); ok {
+		return a
+	}
+	return []interface{}{v}
+}
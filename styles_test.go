@@ -0,0 +1,100 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestStyles(t *testing.T) {
+	t.Parallel()
+	t.Run("Merge", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+styles:
+  title:
+    style: bold
+    align: center
+  plain:
+    style: italic
+  primary:
+    importance: high
+defaults:
+  label:
+    wrap: word
+components:
+  action:
+    params:
+      text: {required: true}
+    content:
+      type: button
+      text: ${text}
+root:
+  type: vbox
+  children:
+  - {type: label, class: title}
+  - {type: label, class: [title, plain]}
+  - {type: label, class: title, style: monospace, wrap: off}
+  - label
+  - {type: action, text: Go, class: primary}
+`
+		ctx := errctx.New()
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		objs := roots["root"].(*fyne.Container).Objects
+		require.Len(t, objs, 5)
+
+		title := objs[0].(*widget.Label)
+		require.True(t, title.TextStyle.Bold)
+		require.Equal(t, fyne.TextAlignCenter, title.Alignment)
+		require.Equal(t, fyne.TextWrapWord, title.Wrapping)
+
+		both := objs[1].(*widget.Label)
+		require.True(t, both.TextStyle.Italic)
+		require.False(t, both.TextStyle.Bold)
+		require.Equal(t, fyne.TextAlignCenter, both.Alignment)
+
+		own := objs[2].(*widget.Label)
+		require.True(t, own.TextStyle.Monospace)
+		require.Equal(t, fyne.TextWrapOff, own.Wrapping)
+
+		require.Equal(t, fyne.TextWrapWord, objs[3].(*widget.Label).Wrapping)
+		require.Equal(t, widget.HighImportance, objs[4].(*widget.Button).Importance)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+styles:
+  bad:
+    type: button
+defaults:
+  widget:
+    text: x
+root:
+  type: vbox
+  children:
+  - {type: label, class: missing}
+  - {type: label, class: [bad, 3]}
+  - {type: label, class: {a: b}}
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		out := sb.String()
+		require.Equal(t, 6, ctx.ErrorCount(), out)
+		require.Contains(t, out, "styles.bad.type: 4:5: style \"bad\" may not set \"type\"")
+		require.Contains(t, out, "defaults.widget: 6:3: unknown element \"widget\"")
+		require.Contains(t, out, "root.children[0].class: 11:19: no style \"missing\" defined")
+		require.Contains(t, out, "root.children[1].class[0]: 12:27: no style \"bad\" defined")
+		require.Contains(t, out, "root.children[1].class[1]: 12:32: invalid type integer")
+		require.Contains(t, out, "root.children[2].class: 13:19: invalid type object")
+	})
+}
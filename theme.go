@@ -0,0 +1,299 @@
+package fyneloader
+
+import (
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// themeColorNames maps the color names accepted in theme definitions to the
+// fyne theme color names.
+var themeColorNames = map[string]fyne.ThemeColorName{
+	"background":       theme.ColorNameBackground,
+	"button":           theme.ColorNameButton,
+	"disabled":         theme.ColorNameDisabled,
+	"disabled-button":  theme.ColorNameDisabledButton,
+	"error":            theme.ColorNameError,
+	"focus":            theme.ColorNameFocus,
+	"foreground":       theme.ColorNameForeground,
+	"hover":            theme.ColorNameHover,
+	"input-background": theme.ColorNameInputBackground,
+	"placeholder":      theme.ColorNamePlaceHolder,
+	"pressed":          theme.ColorNamePressed,
+	"primary":          theme.ColorNamePrimary,
+	"scrollbar":        theme.ColorNameScrollBar,
+	"selection":        theme.ColorNameSelection,
+	"shadow":           theme.ColorNameShadow,
+}
+
+// themeSizeNames maps the size names accepted in theme definitions to the fyne
+// theme size names.
+var themeSizeNames = map[string]fyne.ThemeSizeName{
+	"caption-text":    theme.SizeNameCaptionText,
+	"heading-text":    theme.SizeNameHeadingText,
+	"icon":            theme.SizeNameInlineIcon,
+	"input-border":    theme.SizeNameInputBorder,
+	"padding":         theme.SizeNamePadding,
+	"scrollbar":       theme.SizeNameScrollBar,
+	"scrollbar-small": theme.SizeNameScrollBarSmall,
+	"separator":       theme.SizeNameSeparatorThickness,
+	"subheading-text": theme.SizeNameSubHeadingText,
+	"text":            theme.SizeNameText,
+}
+
+// themeFontStyles lists the text styles which may be given a custom font.
+var themeFontStyles = []string{
+	ValueRegular, ValueBold, ValueItalic, ValueBoldItalic, ValueMonospace,
+}
+
+// loadedTheme is a fyne.Theme loaded from a theme definition.
+//
+// Any color, font, icon or size which is not defined is taken from the
+// fallback theme.
+type loadedTheme struct {
+	fallback fyne.Theme
+	colors   map[fyne.ThemeColorName]map[fyne.ThemeVariant]color.Color
+	fonts    map[string]fyne.Resource
+	icons    map[fyne.ThemeIconName]fyne.Resource
+	sizes    map[fyne.ThemeSizeName]float32
+}
+
+func (t *loadedTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if c, ok := t.colors[name][variant]; ok {
+		return c
+	}
+	return t.fallback.Color(name, variant)
+}
+
+func (t *loadedTheme) Font(style fyne.TextStyle) fyne.Resource {
+	name := ValueRegular
+	switch {
+	case style.Monospace:
+		name = ValueMonospace
+	case style.Bold && style.Italic:
+		name = ValueBoldItalic
+	case style.Bold:
+		name = ValueBold
+	case style.Italic:
+		name = ValueItalic
+	}
+	if f, ok := t.fonts[name]; ok && !style.Symbol {
+		return f
+	}
+	return t.fallback.Font(style)
+}
+
+func (t *loadedTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	if r, ok := t.icons[name]; ok {
+		return r
+	}
+	return t.fallback.Icon(name)
+}
+
+func (t *loadedTheme) Size(name fyne.ThemeSizeName) float32 {
+	if s, ok := t.sizes[name]; ok {
+		return s
+	}
+	return t.fallback.Size(name)
+}
+
+// ReadThemeFile reads a theme definition from a YAML or JSON file.
+//
+// The file may either contain the theme definition itself, or have it under a
+// top-level theme key, in which case any other keys are ignored so that the
+// theme of a full document may be read.
+func (l *Loader) ReadThemeFile(ctx *errctx.Context, path string) (fyne.Theme, error) {
+	data, pos, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = errctx.New()
+	}
+	defer pos.install(ctx)()
+	if raw, ok := data[KeyTheme]; ok {
+		ctx.Path.Add(mpath.Key(KeyTheme))
+		defer ctx.Path.Pop()
+		data, err = maputil.AsObject(raw)
+		if err != nil {
+			ctx.Error(err)
+			return nil, err
+		}
+	}
+	return l.UnmarshalTheme(ctx, data), nil
+}
+
+// UnmarshalTheme creates a theme from a theme definition.
+//
+// The definition may contain colors, sizes, fonts and icons; anything which is
+// not defined falls back to the default fyne theme.
+func (l *Loader) UnmarshalTheme(ctx *errctx.Context, data map[string]interface{}) fyne.Theme {
+	t := &loadedTheme{
+		fallback: theme.DefaultTheme(),
+		colors:   map[fyne.ThemeColorName]map[fyne.ThemeVariant]color.Color{},
+		fonts:    map[string]fyne.Resource{},
+		icons:    map[fyne.ThemeIconName]fyne.Resource{},
+		sizes:    map[fyne.ThemeSizeName]float32{},
+	}
+
+	colors := unpack.OptionalObject(ctx, data, KeyColors, nil)
+	ctx.Path.Add(mpath.Key(KeyColors))
+	for k, v := range colors {
+		name, ok := themeColorNames[k]
+		if !ok {
+			ctx.ErrorWithKey(maputil.EnumStringError{Value: k, Enum: themeColorKeys()}, k)
+			continue
+		}
+		ctx.Path.Add(mpath.Key(k))
+		if variants := getThemeColor(ctx, v); variants != nil {
+			t.colors[name] = variants
+		}
+		ctx.Path.Pop()
+	}
+	ctx.Path.Pop()
+
+	sizes := unpack.OptionalObject(ctx, data, KeySizes, nil)
+	ctx.Path.Add(mpath.Key(KeySizes))
+	for k := range sizes {
+		name, ok := themeSizeNames[k]
+		if !ok {
+			ctx.ErrorWithKey(maputil.EnumStringError{Value: k, Enum: themeSizeKeys()}, k)
+			continue
+		}
+		t.sizes[name] = float32(unpack.RequireNumber(ctx, sizes, k))
+	}
+	ctx.Path.Pop()
+
+	fonts := unpack.OptionalObject(ctx, data, KeyFonts, nil)
+	ctx.Path.Add(mpath.Key(KeyFonts))
+	for k := range fonts {
+		if err := maputil.CheckEnum(k, themeFontStyles); err != nil {
+			ctx.ErrorWithKey(err, k)
+			continue
+		}
+		if r := getResource(ctx, fonts, k); r != nil {
+			t.fonts[k] = r
+		}
+	}
+	ctx.Path.Pop()
+
+	icons := unpack.OptionalObject(ctx, data, KeyIcons, nil)
+	ctx.Path.Add(mpath.Key(KeyIcons))
+	for k := range icons {
+		if r := getResource(ctx, icons, k); r != nil {
+			t.icons[fyne.ThemeIconName(k)] = r
+		}
+	}
+	ctx.Path.Pop()
+
+	return t
+}
+
+func themeColorKeys() []string {
+	keys := make([]string, 0, len(themeColorNames))
+	for k := range themeColorNames {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func themeSizeKeys() []string {
+	keys := make([]string, 0, len(themeSizeNames))
+	for k := range themeSizeNames {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getThemeColor interprets a theme color value, which is either a single
+// color used for all variants or an object with light and dark colors.
+func getThemeColor(ctx *errctx.Context, v interface{}) map[fyne.ThemeVariant]color.Color {
+	if s, ok := v.(string); ok {
+		c, err := ParseColor(s)
+		if err != nil {
+			ctx.Error(err)
+			return nil
+		}
+		return map[fyne.ThemeVariant]color.Color{theme.VariantLight: c, theme.VariantDark: c}
+	}
+
+	data, err := maputil.AsObject(v)
+	if err != nil {
+		ctx.Error(maputil.InvalidTypeError{
+			Actual:   maputil.TypeName(v),
+			Expected: []string{maputil.TypeString, maputil.TypeObject},
+		})
+		return nil
+	}
+	variants := map[fyne.ThemeVariant]color.Color{}
+	for k, variant := range map[string]fyne.ThemeVariant{
+		ValueLight: theme.VariantLight,
+		ValueDark:  theme.VariantDark,
+	} {
+		s, ok, err := maputil.GetString(data, k)
+		if err != nil || !ok {
+			ctx.ErrorWithKey(err, k)
+			continue
+		}
+		c, err := ParseColor(s)
+		if err != nil {
+			ctx.ErrorWithKey(err, k)
+			continue
+		}
+		variants[variant] = c
+	}
+	return variants
+}
+
+// getResource loads the file named by the given key as a resource.
+func getResource(ctx *errctx.Context, data map[string]interface{}, key string) fyne.Resource {
+	path := unpack.RequireString(ctx, data, key)
+	if path == "" {
+		return nil
+	}
+	r, err := fyne.LoadResourceFromPath(path)
+	if err != nil {
+		ctx.ErrorWithKey(err, key)
+		return nil
+	}
+	return r
+}
+
+// ParseColor parses a color given as a hex string in one of the forms
+// `#rgb`, `#rgba`, `#rrggbb` or `#rrggbbaa`.
+func ParseColor(s string) (color.Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == len(s) {
+		return nil, InvalidColorError{Value: s}
+	}
+	switch len(hex) {
+	case 3, 4:
+		expanded := make([]byte, 0, 2*len(hex))
+		for i := 0; i < len(hex); i++ {
+			expanded = append(expanded, hex[i], hex[i])
+		}
+		hex = string(expanded)
+	case 6, 8:
+	default:
+		return nil, InvalidColorError{Value: s}
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, InvalidColorError{Value: s}
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
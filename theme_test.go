@@ -0,0 +1,117 @@
+package fyneloader_test
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestTheme(t *testing.T) {
+	t.Parallel()
+	t.Run("Section", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+vars:
+  brand: "#336699"
+theme:
+  colors:
+    primary: ${brand}
+    background:
+      light: "#fff"
+      dark: "#000000cc"
+  sizes:
+    padding: 2
+    text: 16
+root: label
+`
+		ctx := errctx.New()
+		doc, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Len(t, doc.Roots, 1)
+		require.NotNil(t, doc.Theme)
+
+		th := doc.Theme
+		brand := color.NRGBA{R: 0x33, G: 0x66, B: 0x99, A: 0xff}
+		require.Equal(t, brand, th.Color(theme.ColorNamePrimary, theme.VariantLight))
+		require.Equal(t, brand, th.Color(theme.ColorNamePrimary, theme.VariantDark))
+		require.Equal(
+			t, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+			th.Color(theme.ColorNameBackground, theme.VariantLight),
+		)
+		require.Equal(
+			t, color.NRGBA{R: 0, G: 0, B: 0, A: 0xcc},
+			th.Color(theme.ColorNameBackground, theme.VariantDark),
+		)
+		require.Equal(
+			t, theme.DefaultTheme().Color(theme.ColorNameError, theme.VariantDark),
+			th.Color(theme.ColorNameError, theme.VariantDark),
+		)
+		require.Equal(t, float32(2), th.Size(theme.SizeNamePadding))
+		require.Equal(t, float32(16), th.Size(theme.SizeNameText))
+		require.Equal(t, theme.DefaultTheme().Size(theme.SizeNameScrollBar), th.Size(theme.SizeNameScrollBar))
+	})
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "theme.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"theme": {"colors": {"focus": "#abc"}}}`), 0o600))
+
+		ctx := errctx.New()
+		th, err := fyneloader.New().ReadThemeFile(ctx, path)
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Equal(
+			t, color.NRGBA{R: 0xaa, G: 0xbb, B: 0xcc, A: 0xff},
+			th.Color(theme.ColorNameFocus, theme.VariantLight),
+		)
+	})
+	t.Run("FileWrapper", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		path := filepath.Join(dir, "document.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("theme:\n  colors:\n    focus: \"#abc\"\nroot: label\n"), 0o600))
+
+		ctx := errctx.New()
+		th, err := fyneloader.New().ReadThemeFile(ctx, path)
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Equal(
+			t, color.NRGBA{R: 0xaa, G: 0xbb, B: 0xcc, A: 0xff},
+			th.Color(theme.ColorNameFocus, theme.VariantLight),
+		)
+
+		path = filepath.Join(dir, "invalid.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("theme: dark\n"), 0o600))
+		sb := &strings.Builder{}
+		ctx = errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		th, err = fyneloader.New().ReadThemeFile(ctx, path)
+		require.Error(t, err)
+		require.Nil(t, th)
+		require.Equal(t, 1, ctx.ErrorCount())
+		require.Contains(t, sb.String(), "invalid.yaml:1:1: invalid type string; expected object")
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+theme:
+  colors:
+    primary: blue
+  sizes:
+    huge: 100
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, 2, ctx.ErrorCount())
+		require.Contains(t, sb.String(), "theme.colors.primary: 4:5: invalid color \"blue\"")
+		require.Contains(t, sb.String(), "theme.sizes.huge: 6:5: invalid value \"huge\"")
+	})
+}
@@ -0,0 +1,105 @@
+package fyneloader
+
+import (
+	"os"
+	"strings"
+
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+)
+
+// envPrefix is the prefix used to reference environment variables.
+const envPrefix = "env."
+
+// SetVar sets a variable available for interpolation within definition files.
+//
+// Variables set with this function take precedence over those defined in the
+// vars section of a definition file. If the value is nil, the variable is
+// removed.
+func (l *Loader) SetVar(name string, value interface{}) {
+	if value == nil {
+		delete(l.vars, name)
+		return
+	}
+	l.vars[name] = value
+}
+
+// readVars reads the vars section of a definition file.
+func (l *Loader) readVars(ctx *errctx.Context, raw interface{}) {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	for k, v := range data {
+		l.docVars[k] = v
+	}
+}
+
+// lookupVar resolves a variable by name.
+//
+// Names may use dots to reference values nested within object variables, and
+// names starting with `env.` reference environment variables if the loader
+// has EnvVars enabled.
+func (l *Loader) lookupVar(name string) (interface{}, bool) {
+	if strings.HasPrefix(name, envPrefix) && l.EnvVars {
+		v, ok := os.LookupEnv(name[len(envPrefix):])
+		return v, ok
+	}
+	if v, ok := lookupIn(l.vars, name); ok {
+		return v, true
+	}
+	return lookupIn(l.docVars, name)
+}
+
+// lookup resolves a reference by name, searching the innermost scope of any
+// component or repeat element being expanded before the loader variables.
+func (l *Loader) lookup(name string) (interface{}, bool) {
+	for i := len(l.scopes) - 1; i >= 0; i-- {
+		if v, ok := lookupIn(l.scopes[i], name); ok {
+			return v, true
+		}
+	}
+	return l.lookupVar(name)
+}
+
+// lookupIn resolves a possibly dotted name within the given map.
+func lookupIn(m map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := m[name]; ok {
+		return v, true
+	}
+
+	parts := strings.Split(name, ".")
+	var cur interface{} = m
+	for _, p := range parts {
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = obj[p]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// substituteVars expands all variable references and resolves all translations
+// within the given definition.
+//
+// The vars and components sections are left as-is, as the former defines the
+// variables and the latter is expanded each time a component is used.
+func (l *Loader) substituteVars(ctx *errctx.Context, data map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		if k == KeyVars || k == KeyComponents {
+			result[k] = v
+			continue
+		}
+		ctx.Path.Add(mpath.Key(k))
+		result[k] = l.substitute(ctx, v, l.lookupVar)
+		ctx.Path.Pop()
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package fyneloader_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestVars(t *testing.T) {
+	t.Parallel()
+	t.Run("Interpolate", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+vars:
+  name: World
+  user:
+    admin: true
+root:
+  type: vbox
+  children:
+  - type: label
+    text: "Hello, ${name}! $${name}"
+  - type: button
+    text: "${greeting}"
+    hidden: ${user.admin}
+`
+		l := fyneloader.New()
+		l.SetVar("greeting", "Hi")
+		ctx := errctx.New()
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+
+		box := roots["root"].(*fyne.Container)
+		require.Equal(t, "Hello, World! ${name}", box.Objects[0].(*widget.Label).Text)
+		btn := box.Objects[1].(*widget.Button)
+		require.Equal(t, "Hi", btn.Text)
+		require.True(t, btn.Hidden)
+	})
+	t.Run("Precedence", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+vars:
+  name: Document
+root:
+  type: label
+  text: ${name}
+`
+		l := fyneloader.New()
+		l.SetVar("name", "Loader")
+		roots, err := l.ReadYAML(errctx.New(), strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Equal(t, "Loader", roots["root"].(*widget.Label).Text)
+	})
+	t.Run("Env", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: label
+  text: ${env.PATH}
+`
+		l := fyneloader.New()
+		ctx := errctx.New()
+		_, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, 1, ctx.ErrorCount())
+
+		l.EnvVars = true
+		roots, err := l.ReadYAML(ctx, strings.NewReader(doc))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Equal(t, os.Getenv("PATH"), roots["root"].(*widget.Label).Text)
+	})
+	t.Run("Undefined", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+root:
+  type: vbox
+  children:
+  - type: label
+    text: "Hello, ${name}"
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, "root.children[0].text: 6:5: undefined reference \"name\"\n", sb.String())
+		label := roots["root"].(*fyne.Container).Objects[0].(*widget.Label)
+		require.Equal(t, "Hello, ${name}", label.Text)
+	})
+	t.Run("Unterminated", func(t *testing.T) {
+		t.Parallel()
+		ctx := errctx.New()
+		roots, err := fyneloader.New().ReadYAML(ctx, strings.NewReader("root: {type: label, text: \"a $${b} ${c\"}"))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, fyneloader.ErrUnterminatedReference, ctx.LastError())
+		require.Equal(t, "a $${b} ${c", roots["root"].(*widget.Label).Text)
+	})
+}
@@ -0,0 +1,184 @@
+package fyneloader
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/tvarney/maputil"
+	"github.com/tvarney/maputil/errctx"
+	"github.com/tvarney/maputil/mpath"
+	"github.com/tvarney/maputil/unpack"
+)
+
+// Window describes a window defined in the windows section of a definition
+// file.
+type Window struct {
+	Title      string
+	Size       fyne.Size
+	FixedSize  bool
+	Padded     bool
+	FullScreen bool
+	Master     bool
+	Content    fyne.CanvasObject
+	MainMenu   *fyne.MainMenu
+	Shortcuts  []Shortcut
+}
+
+// Create creates a new window on the given app as described by w.
+//
+// The window is not shown.
+func (w *Window) Create(app fyne.App) fyne.Window {
+	win := app.NewWindow(w.Title)
+	if w.Content != nil {
+		win.SetContent(w.Content)
+	}
+	if w.MainMenu != nil {
+		win.SetMainMenu(w.MainMenu)
+	}
+	if !w.Size.IsZero() {
+		win.Resize(w.Size)
+	}
+	win.SetFixedSize(w.FixedSize)
+	win.SetPadded(w.Padded)
+	win.SetFullScreen(w.FullScreen)
+	if w.Master {
+		win.SetMaster()
+	}
+	AddShortcuts(win.Canvas(), w.Shortcuts)
+	return win
+}
+
+// CreateWindows creates all windows defined by the document on the given app,
+// returning them by name.
+//
+// The shortcuts declared at the root of the definition are added to each
+// window, except those for a key combination which the window declares itself.
+// The windows are not shown.
+func (d *Document) CreateWindows(app fyne.App) map[string]fyne.Window {
+	windows := make(map[string]fyne.Window, len(d.Windows))
+	for name, w := range d.Windows {
+		win := w.Create(app)
+		AddShortcuts(win.Canvas(), inheritedShortcuts(d.Shortcuts, w.Shortcuts))
+		windows[name] = win
+	}
+	return windows
+}
+
+// inheritedShortcuts returns the shortcuts of the document which are not
+// overridden by those of a window.
+func inheritedShortcuts(shortcuts, overrides []Shortcut) []Shortcut {
+	names := make(map[string]struct{}, len(overrides))
+	for _, s := range overrides {
+		names[s.Shortcut.ShortcutName()] = struct{}{}
+	}
+	inherited := make([]Shortcut, 0, len(shortcuts))
+	for _, s := range shortcuts {
+		if _, ok := names[s.Shortcut.ShortcutName()]; !ok {
+			inherited = append(inherited, s)
+		}
+	}
+	return inherited
+}
+
+// Run creates and shows all windows defined by the document, then runs the
+// app until it quits.
+func (d *Document) Run(app fyne.App) {
+	for _, win := range d.CreateWindows(app) {
+		win.Show()
+	}
+	app.Run()
+}
+
+// readWindows reads the windows section of a definition file.
+func (l *Loader) readWindows(ctx *errctx.Context, raw interface{}, doc *Document) map[string]*Window {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	windows := make(map[string]*Window, len(data))
+	master := ""
+	for _, name := range sortedKeys(data) {
+		ctx.Path.Add(mpath.Key(name))
+		if w := l.readWindow(ctx, data[name], doc); w != nil {
+			if w.Master && master != "" {
+				ctx.ErrorWithKey(DuplicateMasterError{Window: name, Master: master}, KeyMaster)
+				w.Master = false
+			} else if w.Master {
+				master = name
+			}
+			windows[name] = w
+		}
+		ctx.Path.Pop()
+	}
+	return windows
+}
+
+func (l *Loader) readWindow(ctx *errctx.Context, raw interface{}, doc *Document) *Window {
+	data, err := maputil.AsObject(raw)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	w := &Window{
+		Title:      unpack.OptionalString(ctx, data, KeyTitle, ""),
+		FixedSize:  unpack.OptionalBoolean(ctx, data, KeyFixedSize, false),
+		Padded:     unpack.OptionalBoolean(ctx, data, KeyPadded, true),
+		FullScreen: unpack.OptionalBoolean(ctx, data, KeyFullScreen, false),
+		Master:     unpack.OptionalBoolean(ctx, data, KeyMaster, false),
+	}
+	if size := unpack.OptionalObject(ctx, data, KeySize, nil); size != nil {
+		ctx.Path.Add(mpath.Key(KeySize))
+		w.Size = fyne.NewSize(
+			float32(unpack.RequireNumber(ctx, size, KeyWidth)),
+			float32(unpack.RequireNumber(ctx, size, KeyHeight)),
+		)
+		ctx.Path.Pop()
+	}
+
+	w.Content = l.getContent(ctx, data, doc.Roots)
+
+	if raw, ok := data[KeyShortcuts]; ok {
+		ctx.Path.Add(mpath.Key(KeyShortcuts))
+		w.Shortcuts = l.readShortcuts(ctx, raw)
+		ctx.Path.Pop()
+	}
+
+	if name := unpack.OptionalString(ctx, data, KeyMainMenu, ""); name != "" {
+		menu, ok := doc.MainMenus[name]
+		if !ok {
+			ctx.ErrorWithKey(UndefinedMenuError{Name: name}, KeyMainMenu)
+		} else {
+			w.MainMenu = menu
+		}
+	}
+	return w
+}
+
+// getContent fetches the value of the content key, which is either the name of
+// a root element or an element definition. Each root may only be used as
+// content once, as an element may only have one parent.
+func (l *Loader) getContent(
+	ctx *errctx.Context, data map[string]interface{}, roots map[string]fyne.CanvasObject,
+) fyne.CanvasObject {
+	switch content := data[KeyContent].(type) {
+	case nil:
+		return nil
+	case string:
+		root, ok := roots[content]
+		if !ok {
+			ctx.ErrorWithKey(UnknownTargetError{ID: content}, KeyContent)
+			return nil
+		}
+		if _, ok := l.contents[content]; ok {
+			ctx.ErrorWithKey(DuplicateContentError{Root: content}, KeyContent)
+			return nil
+		}
+		l.contents[content] = struct{}{}
+		return root
+	default:
+		ctx.Path.Add(mpath.Key(KeyContent))
+		defer ctx.Path.Pop()
+		return l.Unpack(ctx, content)
+	}
+}
@@ -0,0 +1,115 @@
+package fyneloader_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/fyneloader"
+	"github.com/tvarney/maputil/errctx"
+)
+
+func TestWindows(t *testing.T) {
+	t.Parallel()
+	t.Run("Create", func(t *testing.T) {
+		t.Parallel()
+		const def = `
+main-menus:
+  main:
+  - label: File
+    items: [{label: Quit, quit: true}]
+windows:
+  main:
+    title: Main
+    size: {width: 640, height: 480}
+    fixed-size: true
+    padded: false
+    master: true
+    content: home
+    main-menu: main
+  about:
+    title: About
+    content: {type: label, text: About}
+home: label
+`
+		ctx := errctx.New()
+		doc, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(def))
+		require.NoError(t, err)
+		require.Zero(t, ctx.ErrorCount())
+		require.Len(t, doc.Windows, 2)
+
+		main := doc.Windows["main"]
+		require.Equal(t, &fyneloader.Window{
+			Title:     "Main",
+			Size:      fyne.NewSize(640, 480),
+			FixedSize: true,
+			Padded:    false,
+			Master:    true,
+			Content:   doc.Roots["home"],
+			MainMenu:  doc.MainMenus["main"],
+		}, main)
+		require.True(t, doc.Windows["about"].Padded)
+		require.IsType(t, &widget.Label{}, doc.Windows["about"].Content)
+
+		windows := doc.CreateWindows(fyne.CurrentApp())
+		require.Len(t, windows, 2)
+		win := windows["main"]
+		defer win.Close()
+		defer windows["about"].Close()
+		require.True(t, win.FixedSize())
+		require.False(t, win.Padded())
+		require.Same(t, doc.Roots["home"], win.Content())
+		require.Same(t, doc.MainMenus["main"], win.MainMenu())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+windows:
+  main:
+    size: {width: 100}
+    content: missing
+    main-menu: missing
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		_, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(t, 3, ctx.ErrorCount(), sb.String())
+		require.Contains(t, sb.String(), "windows.main.size.height: ")
+		require.Contains(t, sb.String(), "windows.main.content: 5:5: unknown target \"missing\"")
+		require.Contains(t, sb.String(), "windows.main.main-menu: 6:5: no menu \"missing\" defined")
+	})
+	t.Run("Shared", func(t *testing.T) {
+		t.Parallel()
+		const doc = `
+dialogs:
+  info:
+    type: custom
+    content: home
+windows:
+  first:
+    master: true
+    content: home
+  second:
+    master: true
+    content: {type: label}
+home: label
+`
+		sb := &strings.Builder{}
+		ctx := errctx.New(&errctx.ErrorPrinter{Stream: sb})
+		loaded, err := fyneloader.New().LoadYAML(ctx, strings.NewReader(doc))
+		require.IsType(t, fyneloader.LoadError{}, err)
+		require.Equal(
+			t,
+			"windows.first.content: 9:5: root \"home\" is already used as content\n"+
+				"windows.second.master: 11:5: window \"second\" may not be the master window, as \"first\" already is\n",
+			sb.String(),
+		)
+		require.Same(t, loaded.Roots["home"], loaded.Dialogs["info"].Content)
+		require.Nil(t, loaded.Windows["first"].Content)
+		require.True(t, loaded.Windows["first"].Master)
+		require.False(t, loaded.Windows["second"].Master)
+	})
+}